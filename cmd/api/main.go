@@ -10,10 +10,16 @@ import (
 // @title						gohex API
 // @version					1.0
 // @description				gohex – simple Go framework to build APIs
+// @description				Errors are RFC 9457 problem details (application/problem+json) when the Accept header prefers them,
+// @description				unhandled errors always are.
 // @securityDefinitions.apikey	BearerAuth
 // @in							header
 // @name						Authorization
 // @description				Provide your Bearer token in the format: 'Bearer {token}'
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						X-API-Key
+// @description				Provide your API key, or send it as 'Authorization: ApiKey {key}'
 func main() {
 	cfg, err := config.New()
 	if err != nil {
@@ -0,0 +1,96 @@
+// Command errcatalog prints the catalog of the error codes the API can return.
+//
+//	go run ./cmd/errcatalog -format markdown -out docs/errors.md
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	domainErrors "app/internal/core/error"
+
+	// The packages declaring errors register their codes when initialized.
+	_ "app/internal/core/entity"
+	_ "app/internal/core/port"
+	_ "app/internal/presentation/httpfx/handler"
+)
+
+const (
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+)
+
+func main() {
+	format := flag.String("format", formatJSON, "catalog format: json or markdown")
+	out := flag.String("out", "", "output file, stdout when empty")
+	flag.Parse()
+
+	if err := run(*format, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(format, out string) (err error) {
+	w := io.Writer(os.Stdout)
+
+	if out != "" {
+		file, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("create output: %w", err)
+		}
+
+		defer func() {
+			if closeErr := file.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("close output: %w", closeErr)
+			}
+		}()
+
+		w = file
+	}
+
+	definitions := domainErrors.Definitions()
+
+	switch format {
+	case formatJSON:
+		return writeJSON(w, definitions)
+	case formatMarkdown:
+		return writeMarkdown(w, definitions)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func writeJSON(w io.Writer, definitions []domainErrors.Definition) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(definitions); err != nil {
+		return fmt.Errorf("encode catalog: %w", err)
+	}
+
+	return nil
+}
+
+func writeMarkdown(w io.Writer, definitions []domainErrors.Definition) error {
+	if _, err := fmt.Fprint(w, "# Error codes\n\n"+
+		"Generated by `make errors`, do not edit.\n\n"+
+		"| Code | HTTP status | gRPC code | Message |\n"+
+		"| --- | --- | --- | --- |\n",
+	); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	for _, def := range definitions {
+		if _, err := fmt.Fprintf(w, "| `%s` | %d | %s | %s |\n",
+			def.Code, def.HTTPStatus, def.GRPCCode, def.Message,
+		); err != nil {
+			return fmt.Errorf("write %s: %w", def.Code, err)
+		}
+	}
+
+	return nil
+}
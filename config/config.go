@@ -1,9 +1,19 @@
 package config
 
 import (
+	"maps"
+	"os"
+	"time"
+
+	"app/pkg/argon2id"
+	"app/pkg/health"
 	"app/pkg/httpserver"
+	"app/pkg/i18n"
+	"app/pkg/jwt"
 	"app/pkg/logger"
 	"app/pkg/postgres"
+	"app/pkg/ratelimit"
+	"app/pkg/tracing"
 	"app/pkg/tz"
 
 	"github.com/caarlos0/env/v11"
@@ -11,14 +21,63 @@ import (
 )
 
 type Config struct {
-	HTTP     httpserver.Config
-	Postgres postgres.Config
-	Logger   logger.Config
-	Time     tz.Config
+	HTTP      httpserver.Config
+	Admin     httpserver.AdminConfig
+	Postgres  postgres.Config
+	Logger    logger.Config
+	Time      tz.Config
+	Auth      AuthConfig
+	Argon2    argon2id.Config
+	JWT       jwt.Config
+	APIKey    APIKeyConfig
+	Session   SessionConfig
+	Import    UserImportConfig
+	I18N      i18n.Config
+	Health    health.Config
+	Tracing   tracing.Config
+	RateLimit ratelimit.Config
+}
+
+type AuthConfig struct {
+	LockoutMaxFailedAttempts int           `env:"AUTH_LOCKOUT_MAX_FAILED_ATTEMPTS" envDefault:"5"`
+	LockoutDuration          time.Duration `env:"AUTH_LOCKOUT_DURATION" envDefault:"15m"`
+	AccessTokenTTL           time.Duration `env:"AUTH_ACCESS_TOKEN_TTL" envDefault:"15m"`
+	RefreshTokenTTL          time.Duration `env:"AUTH_REFRESH_TOKEN_TTL" envDefault:"720h"`
+}
+
+type APIKeyConfig struct {
+	UsageFlushInterval time.Duration `env:"API_KEY_USAGE_FLUSH_INTERVAL" envDefault:"30s"`
+}
+
+type SessionConfig struct {
+	IdleTimeout     time.Duration `env:"SESSION_IDLE_TIMEOUT" envDefault:"30m"`
+	MaxLifetime     time.Duration `env:"SESSION_MAX_LIFETIME" envDefault:"12h"`
+	TouchInterval   time.Duration `env:"SESSION_TOUCH_INTERVAL" envDefault:"1m"`
+	CleanupInterval time.Duration `env:"SESSION_CLEANUP_INTERVAL" envDefault:"1h"`
 }
 
+// UserImportConfig bounds the user import uploads, read whole before the users are stored.
+type UserImportConfig struct {
+	MaxSize int64 `env:"USER_IMPORT_MAX_SIZE" envDefault:"5242880"` // 5 MB
+	MaxRows int   `env:"USER_IMPORT_MAX_ROWS" envDefault:"10000"`
+}
+
+const dotenvFile = ".env"
+
 func New() (Config, error) {
-	_ = godotenv.Load(".env")
+	_ = godotenv.Load(dotenvFile)
 
 	return env.ParseAs[Config]()
 }
+
+// NewLogger reads the logger configuration again, to reload it at runtime. The variables
+// of the .env file take precedence, since the environment of a process cannot change.
+func NewLogger() (logger.Config, error) {
+	environment := env.ToMap(os.Environ())
+
+	if dotenv, err := godotenv.Read(dotenvFile); err == nil {
+		maps.Copy(environment, dotenv)
+	}
+
+	return env.ParseAsWithOptions[logger.Config](env.Options{Environment: environment})
+}
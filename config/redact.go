@@ -0,0 +1,92 @@
+package config
+
+import (
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Redacted returns the effective configuration keyed by environment variable.
+// Secrets, the variables parsed with the unset option, are redacted, and so are the
+// credentials and query of the URLs found in the other values.
+func (c *Config) Redacted() map[string]any {
+	values := make(map[string]any)
+	redactStruct(reflect.ValueOf(c).Elem(), values)
+
+	return values
+}
+
+func redactStruct(val reflect.Value, values map[string]any) {
+	for i := range val.NumField() {
+		field, fieldVal := val.Type().Field(i), val.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("env")
+		if !ok {
+			if fieldVal.Kind() == reflect.Struct {
+				redactStruct(fieldVal, values)
+			}
+
+			continue
+		}
+
+		key, opts, _ := strings.Cut(tag, ",")
+		if slices.Contains(strings.Split(opts, ","), "unset") {
+			values[key] = redacted
+
+			continue
+		}
+
+		values[key] = redactURLs(fieldVal.Interface())
+	}
+}
+
+// redactURLs redacts the URLs of strings, string slices and string maps.
+func redactURLs(value any) any {
+	switch value := value.(type) {
+	case string:
+		return redactURL(value)
+	case []string:
+		redactedValues := make([]string, len(value))
+		for i, v := range value {
+			redactedValues[i] = redactURL(v)
+		}
+
+		return redactedValues
+	case map[string]string:
+		redactedValues := make(map[string]string, len(value))
+		for k, v := range value {
+			redactedValues[k] = redactURL(v)
+		}
+
+		return redactedValues
+	default:
+		return value
+	}
+}
+
+// redactURL redacts the userinfo and the query of a URL, which may carry credentials.
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" || (u.User == nil && u.RawQuery == "") {
+		return value
+	}
+
+	hasUser := u.User != nil
+	u.User = nil
+
+	if u.RawQuery != "" {
+		u.RawQuery = redacted
+	}
+
+	if !hasUser {
+		return u.String()
+	}
+
+	return u.Scheme + "://" + redacted + "@" + strings.TrimPrefix(u.String(), u.Scheme+"://")
+}
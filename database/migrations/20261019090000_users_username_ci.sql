@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users DROP CONSTRAINT users_username_key;
+CREATE UNIQUE INDEX users_username_lower_key ON users (lower(username));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX users_username_lower_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE roles (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    -- default roles are granted to every user without an explicit assignment
+    is_default BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE permissions (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE role_permissions (
+    role_id INTEGER NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    permission TEXT NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE user_roles (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role_id INTEGER NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX user_roles_role_id_idx ON user_roles (role_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:read', 'List and view users'),
+    ('users:import', 'Bulk import users'),
+    ('users:export', 'Export users');
+
+INSERT INTO roles (name, is_default) VALUES
+    ('user', TRUE),
+    ('admin', FALSE);
+
+-- the default role grants no permission, users reading themselves without users:read
+INSERT INTO role_permissions (role_id, permission)
+SELECT roles.id, permissions.name
+FROM roles, permissions
+WHERE roles.name = 'admin';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_roles;
+DROP TABLE role_permissions;
+DROP TABLE permissions;
+DROP TABLE roles;
+-- +goose StatementEnd
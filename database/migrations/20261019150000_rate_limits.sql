@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the state is short-lived, losing it on a crash only resets the limits
+CREATE UNLOGGED TABLE rate_limits (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL DEFAULT 0,
+    window_start TIMESTAMPTZ,
+    count INTEGER NOT NULL DEFAULT 0,
+    prev_count INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- whether the last request of the key was allowed, returned by the upsert taking it
+    allowed BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE INDEX rate_limits_expires_at_idx ON rate_limits (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE rate_limits;
+-- +goose StatementEnd
@@ -6,10 +6,26 @@ import (
 
 type Application struct {
 	UserService port.UserService
+	AuthService port.AuthService
+	Authorizer  port.Authorizer
+
+	APIKeyService  port.APIKeyService
+	SessionService port.SessionService
 }
 
-func NewApplication(userService port.UserService) *Application {
+func NewApplication(
+	userService port.UserService,
+	authService port.AuthService,
+	authorizer port.Authorizer,
+	apiKeyService port.APIKeyService,
+	sessionService port.SessionService,
+) *Application {
 	return &Application{
 		UserService: userService,
+		AuthService: authService,
+		Authorizer:  authorizer,
+
+		APIKeyService:  apiKeyService,
+		SessionService: sessionService,
 	}
 }
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"app/internal/core/entity"
+)
+
+type CreateAPIKey struct {
+	Name      string
+	Scopes    []entity.Permission
+	ExpiresAt *time.Time
+}
+
+// CreatedAPIKey carries the full key, which is only available at creation.
+type CreatedAPIKey struct {
+	APIKey *entity.APIKey
+	Key    string
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+type Login struct {
+	Username string
+	Password string
+}
+
+type AuthTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
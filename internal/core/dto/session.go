@@ -0,0 +1,15 @@
+package dto
+
+import "app/internal/core/entity"
+
+type StartSession struct {
+	Login     Login
+	UserAgent string
+	IP        string
+}
+
+// CreatedSession carries the session secret, which is only available at creation.
+type CreatedSession struct {
+	Session *entity.Session
+	Token   string
+}
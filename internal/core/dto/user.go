@@ -1,5 +1,52 @@
 package dto
 
+import (
+	"io"
+	"time"
+)
+
 type CreateUser struct {
 	Username string
+	// Password is optional, users without one cannot log in.
+	Password string
+}
+
+// UserFilter narrows down the users returned by listing and export use-cases.
+type UserFilter struct {
+	// Username matches users whose username contains the value, case-insensitively.
+	Username      string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+type ListUsers struct {
+	Filter UserFilter
+	Limit  uint64
+	Offset uint64
+}
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+)
+
+type ImportUsers struct {
+	Format ImportFormat
+	Source io.Reader
+}
+
+// ImportUsersRowError describes a rejected or duplicate line of an import upload.
+type ImportUsersRowError struct {
+	Line     int
+	Username string
+	Err      error
+}
+
+type ImportUsersReport struct {
+	Total      int
+	Imported   int
+	Rejected   []ImportUsersRowError
+	Duplicates []ImportUsersRowError
 }
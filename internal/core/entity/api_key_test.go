@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Minute)
+	scopes := []Permission{PermissionUsersRead}
+
+	testCases := []struct {
+		name        string
+		keyName     string
+		scopes      []Permission
+		expiresAt   *time.Time
+		expectedErr error
+	}{
+		{name: "Valid", keyName: " ci ", scopes: scopes},
+		{name: "Missing Name", keyName: " ", scopes: scopes, expectedErr: ErrAPIKeyNameRequired},
+		{name: "Long Name", keyName: strings.Repeat("a", 65), scopes: scopes, expectedErr: ErrAPIKeyNameTooLong},
+		{name: "No Scopes", keyName: "ci", expectedErr: ErrAPIKeyScopesRequired},
+		{
+			name:        "Unknown Scope",
+			keyName:     "ci",
+			scopes:      []Permission{"users:delete"},
+			expectedErr: ErrAPIKeyUnknownScope,
+		},
+		{name: "Expired", keyName: "ci", scopes: scopes, expiresAt: &past, expectedErr: ErrAPIKeyExpiryInPast},
+		{name: "Every Field Invalid", keyName: " ", expiresAt: &past, expectedErr: ErrAPIKeyExpiryInPast},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, value, err := NewAPIKey(uuid.New(), tc.keyName, tc.scopes, tc.expiresAt)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, key)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "ci", key.Name)
+
+			prefix, secret, ok := ParseAPIKey(value)
+			require.True(t, ok)
+			assert.Equal(t, key.Prefix, prefix)
+			assert.True(t, key.MatchesSecret(secret))
+			assert.False(t, key.MatchesSecret(secret+"x"))
+		})
+	}
+}
+
+func TestParseAPIKey(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", "gohex_", "gohex_abc", "gohex_.secret", "gohex_abc.", "other_abc.secret"} {
+		_, _, ok := ParseAPIKey(value)
+		assert.False(t, ok, value)
+	}
+
+	prefix, secret, ok := ParseAPIKey("gohex_abc.se.cret")
+	assert.True(t, ok)
+	assert.Equal(t, "abc", prefix)
+	assert.Equal(t, "se.cret", secret)
+}
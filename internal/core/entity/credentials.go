@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+	"unicode/utf8"
+
+	domainErrors "app/internal/core/error"
+	"app/internal/types"
+)
+
+const (
+	PasswordMinLength = 8
+	PasswordMaxLength = 128
+
+	passwordField = "password"
+)
+
+var (
+	ErrPasswordTooShort = domainErrors.Register("credentials.password_too_short", domainErrors.KindInvalid, "password is too short").
+				SetField(passwordField)
+	ErrPasswordTooLong = domainErrors.Register("credentials.password_too_long", domainErrors.KindInvalid, "password is too long").
+				SetField(passwordField)
+)
+
+// Credentials holds the password of a user and the state of failed login attempts.
+type Credentials struct {
+	UserID         types.ID
+	PasswordHash   string
+	FailedAttempts int
+	LockedUntil    *time.Time
+	UpdatedAt      time.Time
+}
+
+// LockoutPolicy defines how many consecutive failed logins lock the credentials and for how long.
+type LockoutPolicy struct {
+	MaxFailedAttempts int
+	Duration          time.Duration
+}
+
+func NewCredentials(userID types.ID, passwordHash string) *Credentials {
+	return &Credentials{
+		UserID:       userID,
+		PasswordHash: passwordHash,
+	}
+}
+
+func ValidatePassword(password string) error {
+	length := utf8.RuneCountInString(password)
+
+	switch {
+	case length < PasswordMinLength:
+		return ErrPasswordTooShort.With(domainErrors.Arg("min", PasswordMinLength))
+	case length > PasswordMaxLength:
+		return ErrPasswordTooLong.With(domainErrors.Arg("max", PasswordMaxLength))
+	}
+
+	return nil
+}
+
+func (c *Credentials) IsLocked(now time.Time) bool {
+	return c.LockedUntil != nil && now.Before(*c.LockedUntil)
+}
+
+// RegisterFailedAttempt counts a failed login and locks the credentials once the policy limit is reached.
+// Attempts made before an expired lock are forgotten.
+func (c *Credentials) RegisterFailedAttempt(now time.Time, policy LockoutPolicy) {
+	if c.LockedUntil != nil && !now.Before(*c.LockedUntil) {
+		c.FailedAttempts = 0
+		c.LockedUntil = nil
+	}
+
+	c.FailedAttempts++
+
+	if policy.MaxFailedAttempts > 0 && c.FailedAttempts >= policy.MaxFailedAttempts {
+		lockedUntil := now.Add(policy.Duration)
+		c.LockedUntil = &lockedUntil
+	}
+}
+
+// ResetFailedAttempts reports whether there was anything to reset.
+func (c *Credentials) ResetFailedAttempts() bool {
+	if c.FailedAttempts == 0 && c.LockedUntil == nil {
+		return false
+	}
+
+	c.FailedAttempts = 0
+	c.LockedUntil = nil
+
+	return true
+}
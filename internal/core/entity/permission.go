@@ -0,0 +1,33 @@
+package entity
+
+import "slices"
+
+// Permission names an operation a principal may be allowed to perform.
+// Permissions are granted to users through roles.
+type Permission string
+
+const (
+	PermissionUsersRead   Permission = "users:read"
+	PermissionUsersImport Permission = "users:import"
+	PermissionUsersExport Permission = "users:export"
+
+	PermissionSessionsManage Permission = "sessions:manage"
+)
+
+var knownPermissions = PermissionSet{
+	PermissionUsersRead,
+	PermissionUsersImport,
+	PermissionUsersExport,
+	PermissionSessionsManage,
+}
+
+func IsKnownPermission(permission Permission) bool {
+	return knownPermissions.Has(permission)
+}
+
+// PermissionSet is the set of permissions resolved for a principal.
+type PermissionSet []Permission
+
+func (s PermissionSet) Has(permission Permission) bool {
+	return slices.Contains(s, permission)
+}
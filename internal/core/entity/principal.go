@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"context"
+	"slices"
+
+	"app/internal/types"
+)
+
+type PrincipalKind string
+
+const (
+	PrincipalKindUser   PrincipalKind = "user"
+	PrincipalKindAPIKey PrincipalKind = "api_key"
+)
+
+// Principal is the authenticated caller a use-case runs on behalf of,
+// regardless of how the adapter driving the core authenticated it.
+// API keys act on behalf of the user owning them.
+type Principal struct {
+	Kind   PrincipalKind
+	UserID types.ID
+	// Scopes restrict the principal to a subset of its permissions, when not empty.
+	Scopes []string
+	// Permissions are resolved by the authorizer on first use and cached for the
+	// lifetime of the principal, which is a single request.
+	Permissions PermissionSet
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+
+	return principal, ok && principal != nil
+}
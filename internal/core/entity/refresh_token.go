@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"app/internal/types"
+)
+
+const refreshTokenSecretLength = 32
+
+// TokenPolicy defines how long the issued access and refresh tokens live.
+type TokenPolicy struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// RefreshToken is a single-use token exchanged for a new token pair.
+// Tokens issued by rotating each other share a family, so reusing an already
+// rotated token revokes the whole family.
+type RefreshToken struct {
+	ID         types.ID
+	UserID     types.ID
+	FamilyID   types.ID
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy *types.ID
+}
+
+// NewRefreshToken returns the token along with its secret, which is not stored.
+// A zero familyID starts a new family.
+func NewRefreshToken(userID, familyID types.ID, ttl time.Duration) (*RefreshToken, string, error) {
+	secret, err := NewTokenSecret(refreshTokenSecretLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := &RefreshToken{
+		ID:        types.NewID(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashTokenSecret(secret),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if token.FamilyID == (types.ID{}) {
+		token.FamilyID = token.ID
+	}
+
+	return token, secret, nil
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsRotated reports whether the token was already exchanged for a new one.
+func (t *RefreshToken) IsRotated() bool {
+	return t.ReplacedBy != nil
+}
+
+// NewTokenSecret returns a random URL-safe secret of the given number of bytes.
+func NewTokenSecret(length int) (string, error) {
+	raw := make([]byte, length)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate token secret: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// HashTokenSecret hashes high-entropy token secrets for storage and lookup.
+func HashTokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	token, secret, err := NewRefreshToken(userID, uuid.Nil, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, token.FamilyID, "a new login starts its own family")
+	assert.Equal(t, HashTokenSecret(secret), token.TokenHash)
+	assert.NotContains(t, token.TokenHash, secret)
+	assert.False(t, token.IsExpired(time.Now()))
+	assert.True(t, token.IsExpired(time.Now().Add(time.Hour)))
+
+	next, nextSecret, err := NewRefreshToken(userID, token.FamilyID, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, token.FamilyID, next.FamilyID)
+	assert.NotEqual(t, secret, nextSecret)
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"app/internal/types"
+)
+
+const (
+	sessionSecretLength = 32
+	csrfTokenLength     = 32
+)
+
+// SessionPolicy defines how long sessions live. Every use extends a session by
+// IdleTimeout up to MaxLifetime after its creation. Uses are only persisted once
+// TouchInterval has passed since the previous one, to spare a write per request.
+type SessionPolicy struct {
+	IdleTimeout   time.Duration
+	MaxLifetime   time.Duration
+	TouchInterval time.Duration
+}
+
+// Session is a server-side login of a browser. The browser holds the secret in a
+// cookie and proves requests are its own by echoing the CSRF token.
+type Session struct {
+	ID         types.ID
+	UserID     types.ID
+	TokenHash  string
+	CSRFToken  string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+}
+
+// NewSession returns the session along with its secret, which is not stored.
+func NewSession(userID types.ID, userAgent, ip string, policy SessionPolicy) (*Session, string, error) {
+	secret, err := NewTokenSecret(sessionSecretLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	csrfToken, err := NewTokenSecret(csrfTokenLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+
+	session := &Session{
+		ID:         types.NewID(),
+		UserID:     userID,
+		TokenHash:  HashTokenSecret(secret),
+		CSRFToken:  csrfToken,
+		UserAgent:  userAgent,
+		IP:         ip,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	session.ExpiresAt = session.expiry(now, policy)
+
+	return session, secret, nil
+}
+
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// Touch slides the expiration and reports whether the change should be persisted.
+func (s *Session) Touch(now time.Time, policy SessionPolicy) bool {
+	if now.Sub(s.LastSeenAt) < policy.TouchInterval {
+		return false
+	}
+
+	s.LastSeenAt = now
+	s.ExpiresAt = s.expiry(now, policy)
+
+	return true
+}
+
+// MatchesCSRFToken compares the token in constant time.
+func (s *Session) MatchesCSRFToken(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.CSRFToken)) == 1
+}
+
+func (s *Session) Principal() *Principal {
+	return &Principal{
+		Kind:   PrincipalKindUser,
+		UserID: s.UserID,
+	}
+}
+
+func (s *Session) expiry(now time.Time, policy SessionPolicy) time.Time {
+	expiresAt := now.Add(policy.IdleTimeout)
+
+	if maxExpiresAt := s.CreatedAt.Add(policy.MaxLifetime); expiresAt.After(maxExpiresAt) {
+		return maxExpiresAt
+	}
+
+	return expiresAt
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_Touch(t *testing.T) {
+	t.Parallel()
+
+	policy := SessionPolicy{IdleTimeout: 30 * time.Minute, MaxLifetime: time.Hour, TouchInterval: time.Minute}
+
+	session, secret, err := NewSession(uuid.New(), "agent", "127.0.0.1", policy)
+	require.NoError(t, err)
+	assert.Equal(t, HashTokenSecret(secret), session.TokenHash)
+	assert.Equal(t, session.CreatedAt.Add(policy.IdleTimeout), session.ExpiresAt)
+
+	assert.False(t, session.Touch(session.CreatedAt.Add(30*time.Second), policy), "touches are throttled")
+
+	now := session.CreatedAt.Add(10 * time.Minute)
+	assert.True(t, session.Touch(now, policy))
+	assert.Equal(t, now, session.LastSeenAt)
+	assert.Equal(t, now.Add(policy.IdleTimeout), session.ExpiresAt, "expiration slides")
+
+	now = session.CreatedAt.Add(50 * time.Minute)
+	assert.True(t, session.Touch(now, policy))
+	assert.Equal(t, session.CreatedAt.Add(policy.MaxLifetime), session.ExpiresAt, "capped by the max lifetime")
+	assert.True(t, session.IsExpired(session.CreatedAt.Add(policy.MaxLifetime)))
+}
+
+func TestSession_MatchesCSRFToken(t *testing.T) {
+	t.Parallel()
+
+	session, _, err := NewSession(uuid.New(), "", "", SessionPolicy{IdleTimeout: time.Minute, MaxLifetime: time.Hour})
+	require.NoError(t, err)
+
+	assert.True(t, session.MatchesCSRFToken(session.CSRFToken))
+	assert.False(t, session.MatchesCSRFToken(""))
+	assert.False(t, session.MatchesCSRFToken(session.CSRFToken+"x"))
+}
@@ -1,22 +1,100 @@
 package entity
 
 import (
+	"regexp"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	domainErrors "app/internal/core/error"
 	"app/internal/types"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
 )
 
+const (
+	UsernameMinLength = 3
+	UsernameMaxLength = 32
+
+	usernameField = "username"
+)
+
+var (
+	ErrUsernameTooShort = domainErrors.Register("user.username_too_short", domainErrors.KindInvalid, "username is too short").
+				SetField(usernameField)
+	ErrUsernameTooLong = domainErrors.Register("user.username_too_long", domainErrors.KindInvalid, "username is too long").
+				SetField(usernameField)
+	ErrUsernameInvalidCharacters = domainErrors.Register("user.username_invalid_characters", domainErrors.KindInvalid, "username contains invalid characters").
+					SetField(usernameField)
+	ErrUsernameReserved = domainErrors.Register("user.username_reserved", domainErrors.KindInvalid, "username is reserved").
+				SetField(usernameField)
+)
+
+// usernamePattern allows latin letters, digits and the '.', '_', '-' separators
+// between alphanumeric characters.
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9._-]*[a-zA-Z0-9])?$`)
+
+var reservedUsernames = map[string]struct{}{
+	"admin":         {},
+	"administrator": {},
+	"api":           {},
+	"me":            {},
+	"null":          {},
+	"root":          {},
+	"support":       {},
+	"system":        {},
+	"undefined":     {},
+}
+
+// ImportPolicy bounds the size and the rows of an import upload, zero meaning no limit.
+type ImportPolicy struct {
+	MaxSize int64
+	MaxRows int
+}
+
 type User struct {
 	ID        uuid.UUID
 	Username  string
 	CreatedAt time.Time
 }
 
-func NewUser(username string) *User {
+func NewUser(username string) (*User, error) {
+	username = NormalizeUsername(username)
+
+	if err := ValidateUsername(username); err != nil {
+		return nil, err
+	}
+
 	return &User{
 		ID:       types.NewID(),
 		Username: username,
+	}, nil
+}
+
+// NormalizeUsername applies Unicode NFKC normalization and trims surrounding whitespace,
+// so visually identical usernames (e.g. full-width forms) map to the same value.
+func NormalizeUsername(username string) string {
+	return strings.TrimSpace(norm.NFKC.String(username))
+}
+
+// ValidateUsername checks a normalized username against the domain rules.
+// Uniqueness is case-insensitive and enforced by the storage.
+func ValidateUsername(username string) error {
+	length := utf8.RuneCountInString(username)
+
+	switch {
+	case length < UsernameMinLength:
+		return ErrUsernameTooShort.With(domainErrors.Arg("min", UsernameMinLength))
+	case length > UsernameMaxLength:
+		return ErrUsernameTooLong.With(domainErrors.Arg("max", UsernameMaxLength))
+	case !usernamePattern.MatchString(username):
+		return ErrUsernameInvalidCharacters.With(domainErrors.Arg("allowed", "a-z A-Z 0-9 . _ -"))
 	}
+
+	if _, ok := reservedUsernames[strings.ToLower(username)]; ok {
+		return ErrUsernameReserved
+	}
+
+	return nil
 }
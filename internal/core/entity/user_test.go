@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUser(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		input            string
+		expectedUsername string
+		expectedErr      error
+	}{
+		{
+			name:             "Valid",
+			input:            "john.doe_1",
+			expectedUsername: "john.doe_1",
+		},
+		{
+			name:             "Trims Whitespace",
+			input:            "  alice  ",
+			expectedUsername: "alice",
+		},
+		{
+			name:             "NFKC Normalization",
+			input:            "ｂｏｂ",
+			expectedUsername: "bob",
+		},
+		{
+			name:        "Too Short",
+			input:       "ab",
+			expectedErr: ErrUsernameTooShort,
+		},
+		{
+			name:        "Too Long",
+			input:       strings.Repeat("a", UsernameMaxLength+1),
+			expectedErr: ErrUsernameTooLong,
+		},
+		{
+			name:        "Invalid Characters",
+			input:       "john doe",
+			expectedErr: ErrUsernameInvalidCharacters,
+		},
+		{
+			name:        "Leading Separator",
+			input:       ".john",
+			expectedErr: ErrUsernameInvalidCharacters,
+		},
+		{
+			name:        "Non Latin Letters",
+			input:       "иван",
+			expectedErr: ErrUsernameInvalidCharacters,
+		},
+		{
+			name:        "Reserved Case Insensitive",
+			input:       "Admin",
+			expectedErr: ErrUsernameReserved,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			user, err := NewUser(tc.input)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, user)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedUsername, user.Username)
+		})
+	}
+}
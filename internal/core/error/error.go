@@ -1,6 +1,8 @@
 package error
 
-import "fmt"
+import (
+	"fmt"
+)
 
 type DomainErrorArg struct {
 	Key   string
@@ -10,13 +12,14 @@ type DomainErrorArg struct {
 type DomainError struct {
 	args    []DomainErrorArg
 	message string
-	code    int
+	code    string
+	field   string
 
 	parent error
 }
 
 func (e *DomainError) Error() string {
-	errorf := "code: %d; message: %s"
+	errorf := "code: %s; message: %s"
 	if len(e.args) > 0 {
 		return fmt.Sprintf(errorf+"; args: %v", e.code, e.message, e.args)
 	}
@@ -24,25 +27,32 @@ func (e *DomainError) Error() string {
 	return fmt.Sprintf(errorf, e.code, e.message)
 }
 
-func (e *DomainError) SetCode(code int) *DomainError {
-	e.code = code
+// SetArgs returns a copy of the error carrying the given args in place of its own.
+// The copy unwraps to e, so sentinel errors are not mutated.
+func (e *DomainError) SetArgs(args ...DomainErrorArg) *DomainError {
+	err := e.clone()
+	err.args = args
 
-	return e
+	return err
 }
 
-func (e *DomainError) SetArgs(args ...DomainErrorArg) *DomainError {
-	e.args = args
+// SetMessage returns a copy of the error with the given message, unwrapping to e.
+func (e *DomainError) SetMessage(message string) *DomainError {
+	err := e.clone()
+	err.message = message
 
-	return e
+	return err
 }
 
-func (e *DomainError) SetMessage(message string) *DomainError {
-	e.message = message
+// SetField marks the error as caused by the value of the given input field.
+func (e *DomainError) SetField(field string) *DomainError {
+	e.field = field
 
 	return e
 }
 
-func (e *DomainError) Code() int {
+// Code returns the stable machine-readable code of the error, empty for unregistered errors.
+func (e *DomainError) Code() string {
 	return e.code
 }
 
@@ -50,11 +60,16 @@ func (e *DomainError) Message() string {
 	return e.message
 }
 
+func (e *DomainError) Field() string {
+	return e.field
+}
+
 func (e *DomainError) Wrap(message string) *DomainError {
 	err := &DomainError{
 		code:    e.code,
 		message: fmt.Sprintf("%s: %s", e.message, message),
 		args:    e.args,
+		field:   e.field,
 
 		parent: e,
 	}
@@ -70,10 +85,35 @@ func (e *DomainError) WrapErr(err error) *DomainError {
 	return e.Wrap(err.Error())
 }
 
+// With returns a copy of the error carrying the given args.
+// The copy unwraps to e, so sentinel errors can be enriched without being mutated.
+func (e *DomainError) With(args ...DomainErrorArg) *DomainError {
+	return &DomainError{
+		code:    e.code,
+		message: e.message,
+		args:    append(append([]DomainErrorArg(nil), e.args...), args...),
+		field:   e.field,
+
+		parent: e,
+	}
+}
+
+func (e *DomainError) clone() *DomainError {
+	return &DomainError{
+		code:    e.code,
+		message: e.message,
+		args:    e.args,
+		field:   e.field,
+
+		parent: e,
+	}
+}
+
 func (e *DomainError) Args() []DomainErrorArg {
 	return e.args
 }
 
+// New returns an error without a code. Errors returned to clients should be declared with Register.
 func New(message string, args ...DomainErrorArg) *DomainError {
 	return &DomainError{
 		args:    args,
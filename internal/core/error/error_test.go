@@ -0,0 +1,28 @@
+package error
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainError_Copies(t *testing.T) {
+	t.Parallel()
+
+	sentinel := New("user not found", Arg("id", 1)).SetField("id")
+
+	withArgs := sentinel.SetArgs(Arg("id", 2))
+	withMessage := sentinel.SetMessage("account not found")
+
+	assert.Equal(t, []DomainErrorArg{Arg("id", 2)}, withArgs.Args())
+	assert.Equal(t, "account not found", withMessage.Message())
+	assert.Equal(t, "id", withMessage.Field())
+
+	// the sentinel is left as declared
+	assert.Equal(t, []DomainErrorArg{Arg("id", 1)}, sentinel.Args())
+	assert.Equal(t, "user not found", sentinel.Message())
+
+	require.ErrorIs(t, withArgs, sentinel)
+	require.ErrorIs(t, withMessage, sentinel)
+}
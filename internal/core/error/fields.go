@@ -0,0 +1,57 @@
+package error
+
+import (
+	"errors"
+	"strings"
+)
+
+// FieldErrors aggregates the errors of several invalid input fields,
+// so clients learn about every field to fix at once.
+type FieldErrors []*DomainError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// Join combines the results of independent validations. Nil errors are dropped and
+// a single error is returned as is. Errors that are not field errors take precedence,
+// the first of them is returned, otherwise the field errors are combined into FieldErrors.
+func Join(errs ...error) error {
+	var fieldErrs FieldErrors
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		domainErr, ok := errors.AsType[*DomainError](err)
+		if !ok || domainErr.Field() == "" {
+			return err
+		}
+
+		fieldErrs = append(fieldErrs, domainErr)
+	}
+
+	switch len(fieldErrs) {
+	case 0:
+		return nil
+	case 1:
+		return fieldErrs[0]
+	default:
+		return fieldErrs
+	}
+}
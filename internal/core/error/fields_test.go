@@ -0,0 +1,79 @@
+package error
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errName    = New("name is required").SetField("name")
+		errScopes  = New("scopes are required").SetField("scopes")
+		errUnknown = New("not found")
+		errInfra   = errors.New("connection refused")
+	)
+
+	testCases := []struct {
+		name     string
+		errs     []error
+		expected error
+	}{
+		{
+			name: "No Errors",
+			errs: []error{nil, nil},
+		},
+		{
+			name:     "Single Field Error",
+			errs:     []error{nil, errName},
+			expected: errName,
+		},
+		{
+			name:     "Several Field Errors",
+			errs:     []error{errName, nil, errScopes},
+			expected: FieldErrors{errName, errScopes},
+		},
+		{
+			name:     "Domain Error Without Field",
+			errs:     []error{errName, errUnknown},
+			expected: errUnknown,
+		},
+		{
+			name:     "Other Error",
+			errs:     []error{errName, errInfra},
+			expected: errInfra,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Join(tc.errs...)
+
+			if tc.expected == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestFieldErrors_Is(t *testing.T) {
+	t.Parallel()
+
+	errName := New("name is required").SetField("name")
+	errScopes := New("scopes are required").SetField("scopes")
+
+	err := Join(errName.With(Arg("max", 1)), errScopes)
+
+	require.ErrorIs(t, err, errName)
+	require.ErrorIs(t, err, errScopes)
+}
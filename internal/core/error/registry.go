@@ -0,0 +1,218 @@
+package error
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Kind classifies errors and determines how transports report them.
+type Kind int
+
+const (
+	// KindInvalid is the kind of errors caused by input that violates domain rules.
+	KindInvalid Kind = iota + 1
+	// KindBadRequest is the kind of errors caused by input that cannot be processed at all.
+	KindBadRequest
+	// KindNotFound is the kind of errors caused by a missing resource.
+	KindNotFound
+	// KindConflict is the kind of errors caused by a resource that already exists.
+	KindConflict
+	// KindUnauthenticated is the kind of errors caused by missing or wrong credentials.
+	KindUnauthenticated
+	// KindForbidden is the kind of errors caused by a principal lacking access.
+	KindForbidden
+	// KindLocked is the kind of errors caused by a temporarily locked resource.
+	KindLocked
+	// KindTooManyRequests is the kind of errors caused by a client exceeding its rate limit.
+	KindTooManyRequests
+)
+
+// GRPCCode mirrors the gRPC status codes, so the core does not depend on a gRPC module.
+type GRPCCode uint32
+
+const (
+	GRPCCodeInvalidArgument    GRPCCode = 3
+	GRPCCodeNotFound           GRPCCode = 5
+	GRPCCodeAlreadyExists      GRPCCode = 6
+	GRPCCodePermissionDenied   GRPCCode = 7
+	GRPCCodeResourceExhausted  GRPCCode = 8
+	GRPCCodeFailedPrecondition GRPCCode = 9
+	GRPCCodeInternal           GRPCCode = 13
+	GRPCCodeUnauthenticated    GRPCCode = 16
+)
+
+var grpcCodeNames = map[GRPCCode]string{
+	GRPCCodeInvalidArgument:    "INVALID_ARGUMENT",
+	GRPCCodeNotFound:           "NOT_FOUND",
+	GRPCCodeAlreadyExists:      "ALREADY_EXISTS",
+	GRPCCodePermissionDenied:   "PERMISSION_DENIED",
+	GRPCCodeResourceExhausted:  "RESOURCE_EXHAUSTED",
+	GRPCCodeFailedPrecondition: "FAILED_PRECONDITION",
+	GRPCCodeInternal:           "INTERNAL",
+	GRPCCodeUnauthenticated:    "UNAUTHENTICATED",
+}
+
+func (c GRPCCode) String() string {
+	if name, ok := grpcCodeNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("CODE(%d)", uint32(c))
+}
+
+func (c GRPCCode) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// HTTPStatus returns the HTTP status reported for errors of the kind.
+func (k Kind) HTTPStatus() int {
+	switch k {
+	case KindInvalid:
+		return http.StatusUnprocessableEntity
+	case KindBadRequest:
+		return http.StatusBadRequest
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindUnauthenticated:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindLocked:
+		return http.StatusLocked
+	case KindTooManyRequests:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCCode returns the gRPC status code reported for errors of the kind.
+func (k Kind) GRPCCode() GRPCCode {
+	switch k {
+	case KindInvalid, KindBadRequest:
+		return GRPCCodeInvalidArgument
+	case KindNotFound:
+		return GRPCCodeNotFound
+	case KindConflict:
+		return GRPCCodeAlreadyExists
+	case KindUnauthenticated:
+		return GRPCCodeUnauthenticated
+	case KindForbidden:
+		return GRPCCodePermissionDenied
+	case KindLocked:
+		return GRPCCodeFailedPrecondition
+	case KindTooManyRequests:
+		return GRPCCodeResourceExhausted
+	default:
+		return GRPCCodeInternal
+	}
+}
+
+// Definition describes a registered error code.
+type Definition struct {
+	Code       string   `json:"code"`
+	HTTPStatus int      `json:"http_status"`
+	GRPCCode   GRPCCode `json:"grpc_code"`
+	Message    string   `json:"message"`
+}
+
+// codePattern accepts dot separated snake case segments, e.g. user.not_found.
+var codePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)+$`)
+
+// Registry holds the definitions of the error codes, each code being registered once.
+type Registry struct {
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+func NewRegistry() *Registry {
+	return &Registry{definitions: make(map[string]Definition)}
+}
+
+// Register adds the definition, failing on malformed or already registered codes.
+func (r *Registry) Register(def Definition) error {
+	if !codePattern.MatchString(def.Code) {
+		return fmt.Errorf("error code %q is malformed", def.Code)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.definitions[def.Code]; ok {
+		return fmt.Errorf("error code %q is already registered for %q", def.Code, existing.Message)
+	}
+
+	r.definitions[def.Code] = def
+
+	return nil
+}
+
+func (r *Registry) Lookup(code string) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, ok := r.definitions[code]
+
+	return def, ok
+}
+
+// Definitions returns the registered definitions sorted by code.
+func (r *Registry) Definitions() []Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]Definition, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		defs = append(defs, def)
+	}
+
+	slices.SortFunc(defs, func(a, b Definition) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	return defs
+}
+
+// MustRegister registers the code with the HTTP status and gRPC code of its kind and returns
+// the error carrying it. It panics when the code is malformed or already taken, so conflicts
+// surface when the program starts.
+func (r *Registry) MustRegister(code string, kind Kind, message string) *DomainError {
+	err := r.Register(Definition{
+		Code:       code,
+		HTTPStatus: kind.HTTPStatus(),
+		GRPCCode:   kind.GRPCCode(),
+		Message:    message,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return &DomainError{
+		code:    code,
+		message: message,
+	}
+}
+
+var defaultRegistry = NewRegistry()
+
+// Register declares an error with a stable code in the default registry.
+// It is meant for package level sentinels, see MustRegister.
+func Register(code string, kind Kind, message string) *DomainError {
+	return defaultRegistry.MustRegister(code, kind, message)
+}
+
+// Lookup returns the definition of a code of the default registry.
+func Lookup(code string) (Definition, bool) {
+	return defaultRegistry.Lookup(code)
+}
+
+// Definitions returns the definitions of the default registry sorted by code.
+func Definitions() []Definition {
+	return defaultRegistry.Definitions()
+}
@@ -0,0 +1,97 @@
+package error
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		definitions []Definition
+		expectedErr string
+	}{
+		{
+			name:        "Success",
+			definitions: []Definition{{Code: "user.not_found"}, {Code: "user.import.malformed_row"}},
+		},
+		{
+			name:        "Duplicate Code",
+			definitions: []Definition{{Code: "user.not_found"}, {Code: "user.not_found"}},
+			expectedErr: `error code "user.not_found" is already registered`,
+		},
+		{
+			name:        "Missing Namespace",
+			definitions: []Definition{{Code: "not_found"}},
+			expectedErr: `error code "not_found" is malformed`,
+		},
+		{
+			name:        "Upper Case",
+			definitions: []Definition{{Code: "user.NotFound"}},
+			expectedErr: `error code "user.NotFound" is malformed`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			registry := NewRegistry()
+
+			var err error
+			for _, def := range tc.definitions {
+				if err = registry.Register(def); err != nil {
+					break
+				}
+			}
+
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, registry.Definitions(), len(tc.definitions))
+		})
+	}
+}
+
+func TestRegistry_Definitions(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(Definition{Code: "user.not_found"}))
+	require.NoError(t, registry.Register(Definition{Code: "auth.forbidden"}))
+
+	defs := registry.Definitions()
+
+	require.Len(t, defs, 2)
+	assert.Equal(t, "auth.forbidden", defs[0].Code)
+	assert.Equal(t, "user.not_found", defs[1].Code)
+}
+
+func TestRegistry_MustRegister(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	err := registry.MustRegister("test.registered", KindNotFound, "registered").With(Arg("id", 1))
+
+	assert.Equal(t, "test.registered", err.Code())
+
+	def, ok := registry.Lookup(err.Code())
+	require.True(t, ok)
+	assert.Equal(t, Definition{
+		Code:       "test.registered",
+		HTTPStatus: http.StatusNotFound,
+		GRPCCode:   GRPCCodeNotFound,
+		Message:    "registered",
+	}, def)
+
+	assert.Panics(t, func() { registry.MustRegister("test.registered", KindConflict, "registered again") })
+}
@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/types"
+)
+
+var (
+	ErrAPIKeyNotFound = domainErrors.Register("api_key.not_found", domainErrors.KindNotFound, "api key not found")
+	ErrInvalidAPIKey  = domainErrors.Register("api_key.invalid", domainErrors.KindUnauthenticated, "invalid api key")
+	ErrAPIKeyExpired  = domainErrors.Register("api_key.expired", domainErrors.KindUnauthenticated, "api key expired")
+)
+
+type APIKeyService interface {
+	// Create issues a key owned by the current user. Its scopes must be permissions the user has.
+	Create(ctx context.Context, input dto.CreateAPIKey) (*dto.CreatedAPIKey, error)
+	// Authenticate resolves the principal of a full key and records its use.
+	Authenticate(ctx context.Context, key string) (*entity.Principal, error)
+	// FlushUsage persists the last-used times recorded since the previous flush.
+	FlushUsage(ctx context.Context) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+	// TouchLastUsed sets the last-used time of many keys at once, never moving it backwards.
+	TouchLastUsed(ctx context.Context, usage map[types.ID]time.Time) error
+}
@@ -0,0 +1,64 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/types"
+)
+
+var (
+	ErrCredentialsNotFound = domainErrors.Register("credentials.not_found", domainErrors.KindNotFound, "credentials not found")
+	ErrInvalidCredentials  = domainErrors.Register("auth.invalid_credentials", domainErrors.KindUnauthenticated, "invalid username or password")
+
+	ErrUnauthenticated      = domainErrors.Register("auth.unauthenticated", domainErrors.KindUnauthenticated, "authentication required")
+	ErrInvalidToken         = domainErrors.Register("auth.invalid_token", domainErrors.KindUnauthenticated, "invalid token")
+	ErrTokenExpired         = domainErrors.Register("auth.token_expired", domainErrors.KindUnauthenticated, "token expired")
+	ErrRefreshTokenReused   = domainErrors.Register("auth.refresh_token_reused", domainErrors.KindUnauthenticated, "refresh token reuse detected")
+	ErrRefreshTokenNotFound = domainErrors.Register("auth.refresh_token_not_found", domainErrors.KindNotFound, "refresh token not found")
+	ErrForbidden            = domainErrors.Register("auth.forbidden", domainErrors.KindForbidden, "access denied")
+)
+
+type AuthService interface {
+	Login(ctx context.Context, input dto.Login) (*dto.AuthTokens, error)
+	// VerifyCredentials checks the credentials like Login does, lockout included,
+	// without issuing tokens, for adapters establishing their own kind of session.
+	VerifyCredentials(ctx context.Context, input dto.Login) (*entity.User, error)
+	// Refresh rotates the refresh token and issues a new token pair.
+	Refresh(ctx context.Context, refreshToken string) (*dto.AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+	// Authenticate resolves the principal of an access token.
+	Authenticate(ctx context.Context, accessToken string) (*entity.Principal, error)
+}
+
+// PasswordHasher hashes passwords into self-describing encoded strings.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the encoded hash and whether the hash
+	// should be replaced because it was produced with outdated parameters.
+	Verify(password, encodedHash string) (match bool, needsRehash bool, err error)
+}
+
+// AccessTokenIssuer issues and verifies short-lived self-contained access tokens.
+type AccessTokenIssuer interface {
+	Issue(principal *entity.Principal, expiresAt time.Time) (string, error)
+	// Verify returns ErrTokenExpired or ErrInvalidToken for unusable tokens.
+	Verify(token string) (*entity.Principal, error)
+}
+
+type CredentialsRepository interface {
+	// GetForUpdate locks the credentials row until the end of the current transaction.
+	GetForUpdate(ctx context.Context, userID types.ID) (*entity.Credentials, error)
+	Save(ctx context.Context, credentials *entity.Credentials) error
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	// GetByHashForUpdate locks the token row until the end of the current transaction.
+	GetByHashForUpdate(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	Revoke(ctx context.Context, id types.ID, replacedBy *types.ID) error
+	RevokeFamily(ctx context.Context, familyID types.ID) error
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"app/internal/core/entity"
+	"app/internal/types"
+)
+
+// Authorizer decides whether the principal of the context may perform an operation.
+// Services call it themselves, so the policy holds whichever adapter drives the core.
+type Authorizer interface {
+	// Authorize returns ErrUnauthenticated when the context carries no principal and
+	// ErrForbidden, with the missing permission in its args, when the permission is not granted.
+	Authorize(ctx context.Context, permission entity.Permission) error
+}
+
+type PermissionRepository interface {
+	// ListByUserID returns the permissions granted to the user by its roles
+	// and by the default roles every user has.
+	ListByUserID(ctx context.Context, userID types.ID) (entity.PermissionSet, error)
+}
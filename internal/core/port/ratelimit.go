@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	domainErrors "app/internal/core/error"
+	"app/pkg/ratelimit"
+)
+
+var ErrRateLimitExceeded = domainErrors.Register("rate_limit.exceeded", domainErrors.KindTooManyRequests, "too many requests")
+
+// RateLimitStore takes the requests of the keys atomically, even across replicas for a shared store.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error)
+	// DeleteExpired deletes the keys back to their zero state.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,46 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/types"
+)
+
+var (
+	ErrSessionNotFound  = domainErrors.Register("session.not_found", domainErrors.KindNotFound, "session not found")
+	ErrInvalidSession   = domainErrors.Register("session.invalid", domainErrors.KindUnauthenticated, "invalid session")
+	ErrSessionExpired   = domainErrors.Register("session.expired", domainErrors.KindUnauthenticated, "session expired")
+	ErrInvalidCSRFToken = domainErrors.Register("session.invalid_csrf_token", domainErrors.KindForbidden, "invalid csrf token")
+)
+
+type SessionService interface {
+	// Start verifies the credentials and starts a session.
+	Start(ctx context.Context, input dto.StartSession) (*dto.CreatedSession, error)
+	// Authenticate resolves the session of a secret and slides its expiration.
+	Authenticate(ctx context.Context, token string) (*entity.Session, error)
+	// End deletes the session of a secret.
+	End(ctx context.Context, token string) error
+	// List returns the active sessions of a user. Users manage their own sessions,
+	// managing others' requires the sessions:manage permission.
+	List(ctx context.Context, userID types.ID) ([]*entity.Session, error)
+	Revoke(ctx context.Context, userID, sessionID types.ID) error
+	RevokeAll(ctx context.Context, userID types.ID) error
+	// DeleteExpired removes expired sessions and returns how many were removed.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Session, error)
+	// Touch persists the last-seen and expiration times.
+	Touch(ctx context.Context, session *entity.Session) error
+	ListByUserID(ctx context.Context, userID types.ID, now time.Time) ([]*entity.Session, error)
+	Delete(ctx context.Context, userID, id types.ID) error
+	DeleteByTokenHash(ctx context.Context, tokenHash string) error
+	DeleteByUserID(ctx context.Context, userID types.ID) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -10,16 +10,39 @@ import (
 )
 
 var (
-	ErrUserNotFound      = domainErrors.New("user not found")
-	ErrUserAlreadyExists = domainErrors.New("user already exists")
+	ErrUserNotFound      = domainErrors.Register("user.not_found", domainErrors.KindNotFound, "user not found")
+	ErrUserAlreadyExists = domainErrors.Register("user.already_exists", domainErrors.KindConflict, "user already exists")
+
+	ErrUnsupportedImportFormat = domainErrors.Register("user.import.unsupported_format", domainErrors.KindInvalid, "unsupported import format").
+					SetField("format")
+	ErrImportMissingUsernameColumn = domainErrors.Register("user.import.missing_username_column", domainErrors.KindInvalid, "import has no username column")
+	ErrImportMalformedRow          = domainErrors.Register("user.import.malformed_row", domainErrors.KindBadRequest, "malformed row")
+	ErrImportDuplicateInInput      = domainErrors.Register("user.import.duplicate_in_input", domainErrors.KindConflict, "username is duplicated in the import")
+	ErrImportTooLarge              = domainErrors.Register("user.import.too_large", domainErrors.KindInvalid, "import is too large").
+					SetField("file")
+	ErrImportTooManyRows = domainErrors.Register("user.import.too_many_rows", domainErrors.KindInvalid, "import has too many rows").
+				SetField("file")
 )
 
 type UserService interface {
 	Create(ctx context.Context, input dto.CreateUser) (*entity.User, error)
 	GetByID(ctx context.Context, id types.ID) (*entity.User, error)
+	Import(ctx context.Context, input dto.ImportUsers) (*dto.ImportUsersReport, error)
+	List(ctx context.Context, input dto.ListUsers) ([]*entity.User, error)
+	// Export calls fn for every user matching the filter without loading them all into memory.
+	Export(ctx context.Context, filter dto.UserFilter, fn func(*entity.User) error) error
 }
 
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) error
 	GetByID(ctx context.Context, id types.ID) (*entity.User, error)
+	// GetByUsername looks the user up case-insensitively.
+	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+	// CreateMany inserts users skipping the ones conflicting with existing usernames,
+	// which are returned. It must be called within a transaction.
+	CreateMany(ctx context.Context, users []*entity.User) ([]*entity.User, error)
+	List(ctx context.Context, input dto.ListUsers) ([]*entity.User, error)
+	// Stream iterates the users matching the filter through a server-side cursor.
+	// It must be called within a transaction.
+	Stream(ctx context.Context, filter dto.UserFilter, fn func(*entity.User) error) error
 }
@@ -0,0 +1,122 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/types"
+)
+
+type Service struct {
+	apiKeyRepo port.APIKeyRepository
+	authorizer port.Authorizer
+
+	// usage buffers the last-used times between flushes,
+	// so authenticating does not write on every request.
+	usageMu sync.Mutex
+	usage   map[types.ID]time.Time
+}
+
+func NewService(apiKeyRepo port.APIKeyRepository, authorizer port.Authorizer) *Service {
+	return &Service{
+		apiKeyRepo: apiKeyRepo,
+		authorizer: authorizer,
+		usage:      make(map[types.ID]time.Time),
+	}
+}
+
+func (s *Service) Create(ctx context.Context, input dto.CreateAPIKey) (*dto.CreatedAPIKey, error) {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, port.ErrUnauthenticated
+	}
+
+	// A key must not be able to mint keys outliving or outscoping itself.
+	if principal.Kind != entity.PrincipalKindUser {
+		return nil, port.ErrForbidden
+	}
+
+	apiKey, key, err := entity.NewAPIKey(principal.UserID, input.Name, input.Scopes, input.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, scope := range apiKey.Scopes {
+		if err := s.authorizer.Authorize(ctx, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+func (s *Service) Authenticate(ctx context.Context, key string) (*entity.Principal, error) {
+	prefix, secret, ok := entity.ParseAPIKey(key)
+	if !ok {
+		return nil, port.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, port.ErrAPIKeyNotFound) {
+			return nil, port.ErrInvalidAPIKey
+		}
+
+		return nil, err
+	}
+
+	now := time.Now()
+
+	switch {
+	case !apiKey.MatchesSecret(secret) || apiKey.RevokedAt != nil:
+		return nil, port.ErrInvalidAPIKey
+	case apiKey.IsExpired(now):
+		return nil, port.ErrAPIKeyExpired
+	}
+
+	s.recordUsage(apiKey.ID, now)
+
+	return apiKey.Principal(), nil
+}
+
+func (s *Service) FlushUsage(ctx context.Context) error {
+	s.usageMu.Lock()
+	usage := s.usage
+	s.usage = make(map[types.ID]time.Time, len(usage))
+	s.usageMu.Unlock()
+
+	if len(usage) == 0 {
+		return nil
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, usage); err != nil {
+		// Keep the batch for the next flush unless newer uses were recorded meanwhile.
+		s.usageMu.Lock()
+		for id, usedAt := range usage {
+			if current, ok := s.usage[id]; !ok || current.Before(usedAt) {
+				s.usage[id] = usedAt
+			}
+		}
+		s.usageMu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) recordUsage(id types.ID, usedAt time.Time) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	s.usage[id] = usedAt
+}
@@ -0,0 +1,237 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/mocks"
+	"app/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyService_Create(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	userCtx := entity.ContextWithPrincipal(context.Background(),
+		&entity.Principal{Kind: entity.PrincipalKindUser, UserID: userID})
+	input := dto.CreateAPIKey{Name: "ci", Scopes: []entity.Permission{entity.PermissionUsersExport}}
+	errRepoFailed := errors.New("repository failed")
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		setupMock   func(repo *mocks.MockAPIKeyRepository, authorizer *mocks.MockAuthorizer)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			ctx:  userCtx,
+			setupMock: func(repo *mocks.MockAPIKeyRepository, authorizer *mocks.MockAuthorizer) {
+				authorizer.EXPECT().Authorize(userCtx, entity.PermissionUsersExport).Return(nil).Times(1)
+				repo.EXPECT().Create(userCtx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, key *entity.APIKey) error {
+						assert.Equal(t, userID, key.UserID)
+						assert.Equal(t, input.Scopes, key.Scopes)
+
+						return nil
+					},
+				).Times(1)
+			},
+		},
+		{
+			name: "Scope Not Granted",
+			ctx:  userCtx,
+			setupMock: func(_ *mocks.MockAPIKeyRepository, authorizer *mocks.MockAuthorizer) {
+				authorizer.EXPECT().Authorize(userCtx, entity.PermissionUsersExport).Return(port.ErrForbidden).Times(1)
+			},
+			expectedErr: port.ErrForbidden,
+		},
+		{
+			name: "API Key Principal",
+			ctx: entity.ContextWithPrincipal(context.Background(),
+				&entity.Principal{Kind: entity.PrincipalKindAPIKey, UserID: userID}),
+			setupMock:   func(*mocks.MockAPIKeyRepository, *mocks.MockAuthorizer) {},
+			expectedErr: port.ErrForbidden,
+		},
+		{
+			name:        "No Principal",
+			ctx:         context.Background(),
+			setupMock:   func(*mocks.MockAPIKeyRepository, *mocks.MockAuthorizer) {},
+			expectedErr: port.ErrUnauthenticated,
+		},
+		{
+			name: "Repo Error",
+			ctx:  userCtx,
+			setupMock: func(repo *mocks.MockAPIKeyRepository, authorizer *mocks.MockAuthorizer) {
+				authorizer.EXPECT().Authorize(userCtx, gomock.Any()).Return(nil).Times(1)
+				repo.EXPECT().Create(userCtx, gomock.Any()).Return(errRepoFailed).Times(1)
+			},
+			expectedErr: errRepoFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+			mockAuthorizer := mocks.NewMockAuthorizer(ctrl)
+			tc.setupMock(mockRepo, mockAuthorizer)
+
+			created, err := NewService(mockRepo, mockAuthorizer).Create(tc.ctx, input)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, created)
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, created.Key, created.APIKey.Prefix)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	past := time.Now().Add(-time.Minute)
+	errRepoFailed := errors.New("repository failed")
+
+	newKey := func(t *testing.T, modify func(key *entity.APIKey)) (*entity.APIKey, string) {
+		t.Helper()
+
+		key, value, err := entity.NewAPIKey(uuid.New(), "ci", []entity.Permission{entity.PermissionUsersRead}, nil)
+		require.NoError(t, err)
+
+		if modify != nil {
+			modify(key)
+		}
+
+		return key, value
+	}
+
+	testCases := []struct {
+		name        string
+		modify      func(key *entity.APIKey)
+		value       func(value string) string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "Success"},
+		{
+			name:        "Malformed",
+			value:       func(string) string { return "not-a-key" },
+			expectedErr: port.ErrInvalidAPIKey,
+		},
+		{
+			name:        "Wrong Secret",
+			value:       func(value string) string { return value + "x" },
+			expectedErr: port.ErrInvalidAPIKey,
+		},
+		{
+			name:        "Unknown Prefix",
+			repoErr:     port.ErrAPIKeyNotFound,
+			expectedErr: port.ErrInvalidAPIKey,
+		},
+		{
+			name:        "Revoked",
+			modify:      func(key *entity.APIKey) { key.RevokedAt = &past },
+			expectedErr: port.ErrInvalidAPIKey,
+		},
+		{
+			name:        "Expired",
+			modify:      func(key *entity.APIKey) { key.ExpiresAt = &past },
+			expectedErr: port.ErrAPIKeyExpired,
+		},
+		{
+			name:        "Repo Error",
+			repoErr:     errRepoFailed,
+			expectedErr: errRepoFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			key, value := newKey(t, tc.modify)
+			if tc.value != nil {
+				value = tc.value(value)
+			}
+
+			mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+			if _, _, ok := entity.ParseAPIKey(value); ok {
+				if tc.repoErr != nil {
+					mockRepo.EXPECT().GetByPrefix(ctx, key.Prefix).Return(nil, tc.repoErr).Times(1)
+				} else {
+					mockRepo.EXPECT().GetByPrefix(ctx, key.Prefix).Return(key, nil).Times(1)
+				}
+			}
+
+			service := NewService(mockRepo, nil)
+			principal, err := service.Authenticate(ctx, value)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, principal)
+				assert.Empty(t, service.usage)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, &entity.Principal{
+				Kind:   entity.PrincipalKindAPIKey,
+				UserID: key.UserID,
+				Scopes: []string{string(entity.PermissionUsersRead)},
+			}, principal)
+			assert.Contains(t, service.usage, key.ID)
+		})
+	}
+}
+
+func TestAPIKeyService_FlushUsage(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	id := uuid.New()
+	usedAt := time.Now()
+	errRepoFailed := errors.New("repository failed")
+
+	mockRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	service := NewService(mockRepo, nil)
+
+	// Nothing recorded, nothing written.
+	require.NoError(t, service.FlushUsage(ctx))
+
+	service.recordUsage(id, usedAt)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().TouchLastUsed(ctx, map[types.ID]time.Time{id: usedAt}).Return(errRepoFailed).Times(1),
+		mockRepo.EXPECT().TouchLastUsed(ctx, map[types.ID]time.Time{id: usedAt}).Return(nil).Times(1),
+	)
+
+	assert.ErrorIs(t, service.FlushUsage(ctx), errRepoFailed)
+	require.NoError(t, service.FlushUsage(ctx), "a failed batch is retried on the next flush")
+	require.NoError(t, service.FlushUsage(ctx))
+}
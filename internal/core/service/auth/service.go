@@ -0,0 +1,292 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/types"
+	"app/pkg/errtrace"
+	"app/pkg/transactor"
+)
+
+type Service struct {
+	userRepo         port.UserRepository
+	credentialsRepo  port.CredentialsRepository
+	refreshTokenRepo port.RefreshTokenRepository
+	passwordHasher   port.PasswordHasher
+	tokenIssuer      port.AccessTokenIssuer
+	transactor       transactor.Transactor
+	lockoutPolicy    entity.LockoutPolicy
+	tokenPolicy      entity.TokenPolicy
+
+	// dummyHash is verified against when the user does not exist or is locked,
+	// so the response time does not reveal registered usernames nor locked accounts.
+	dummyHash func() (string, error)
+}
+
+func NewService(
+	lockoutPolicy entity.LockoutPolicy,
+	tokenPolicy entity.TokenPolicy,
+	userRepo port.UserRepository,
+	credentialsRepo port.CredentialsRepository,
+	refreshTokenRepo port.RefreshTokenRepository,
+	passwordHasher port.PasswordHasher,
+	tokenIssuer port.AccessTokenIssuer,
+	transactor transactor.Transactor,
+) *Service {
+	return &Service{
+		userRepo:         userRepo,
+		credentialsRepo:  credentialsRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		passwordHasher:   passwordHasher,
+		tokenIssuer:      tokenIssuer,
+		transactor:       transactor,
+		lockoutPolicy:    lockoutPolicy,
+		tokenPolicy:      tokenPolicy,
+		dummyHash: sync.OnceValues(func() (string, error) {
+			return passwordHasher.Hash("dummy password")
+		}),
+	}
+}
+
+func (s *Service) Login(ctx context.Context, input dto.Login) (*dto.AuthTokens, error) {
+	user, err := s.VerifyCredentials(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens *dto.AuthTokens
+
+	err = s.transactor.Do(ctx, func(ctx context.Context) error {
+		tokens, _, err = s.issueTokens(ctx, user.ID, types.ID{})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (s *Service) VerifyCredentials(ctx context.Context, input dto.Login) (*entity.User, error) {
+	user, err := s.userRepo.GetByUsername(ctx, entity.NormalizeUsername(input.Username))
+	if err != nil {
+		if errors.Is(err, port.ErrUserNotFound) {
+			return nil, s.reject(input.Password)
+		}
+
+		return nil, err
+	}
+
+	var loginErr error
+
+	err = s.transactor.Do(ctx, func(ctx context.Context) error {
+		credentials, err := s.credentialsRepo.GetForUpdate(ctx, user.ID)
+		if err != nil {
+			if errors.Is(err, port.ErrCredentialsNotFound) {
+				loginErr = s.reject(input.Password)
+
+				return nil
+			}
+
+			return err
+		}
+
+		err = s.verify(ctx, credentials, input.Password)
+		if errors.Is(err, port.ErrInvalidCredentials) {
+			// Commit the failed attempt instead of rolling it back.
+			loginErr = err
+
+			return nil
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if loginErr != nil {
+		return nil, loginErr
+	}
+
+	return user, nil
+}
+
+func (s *Service) Refresh(ctx context.Context, refreshToken string) (*dto.AuthTokens, error) {
+	var (
+		tokens     *dto.AuthTokens
+		refreshErr error
+	)
+
+	err := s.transactor.Do(ctx, func(ctx context.Context) error {
+		current, err := s.refreshTokenRepo.GetByHashForUpdate(ctx, entity.HashTokenSecret(refreshToken))
+		if err != nil {
+			if errors.Is(err, port.ErrRefreshTokenNotFound) {
+				refreshErr = port.ErrInvalidToken
+
+				return nil
+			}
+
+			return err
+		}
+
+		switch {
+		case current.IsRotated():
+			// A rotated token being presented again means it leaked, so every token
+			// descending from the same login is revoked. The revocation is committed.
+			if err := s.refreshTokenRepo.RevokeFamily(ctx, current.FamilyID); err != nil {
+				return err
+			}
+
+			refreshErr = port.ErrRefreshTokenReused
+
+			return nil
+		case current.RevokedAt != nil:
+			refreshErr = port.ErrInvalidToken
+
+			return nil
+		case current.IsExpired(time.Now()):
+			refreshErr = port.ErrTokenExpired
+
+			return nil
+		}
+
+		var nextID types.ID
+
+		tokens, nextID, err = s.issueTokens(ctx, current.UserID, current.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		return s.refreshTokenRepo.Revoke(ctx, current.ID, &nextID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if refreshErr != nil {
+		return nil, refreshErr
+	}
+
+	return tokens, nil
+}
+
+// Logout revokes every refresh token issued since the login the token belongs to.
+func (s *Service) Logout(ctx context.Context, refreshToken string) error {
+	return s.transactor.Do(ctx, func(ctx context.Context) error {
+		current, err := s.refreshTokenRepo.GetByHashForUpdate(ctx, entity.HashTokenSecret(refreshToken))
+		if err != nil {
+			if errors.Is(err, port.ErrRefreshTokenNotFound) {
+				return port.ErrInvalidToken
+			}
+
+			return err
+		}
+
+		return s.refreshTokenRepo.RevokeFamily(ctx, current.FamilyID)
+	})
+}
+
+func (s *Service) Authenticate(_ context.Context, accessToken string) (*entity.Principal, error) {
+	if accessToken == "" {
+		return nil, port.ErrUnauthenticated
+	}
+
+	return s.tokenIssuer.Verify(accessToken)
+}
+
+// issueTokens issues an access token and stores a new refresh token in the given family,
+// returning the id of the stored refresh token along with the token pair.
+func (s *Service) issueTokens(
+	ctx context.Context,
+	userID, familyID types.ID,
+) (*dto.AuthTokens, types.ID, error) {
+	refreshToken, secret, err := entity.NewRefreshToken(userID, familyID, s.tokenPolicy.RefreshTTL)
+	if err != nil {
+		return nil, types.ID{}, err
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, types.ID{}, err
+	}
+
+	accessTokenExpiresAt := time.Now().Add(s.tokenPolicy.AccessTTL)
+
+	accessToken, err := s.tokenIssuer.Issue(&entity.Principal{
+		Kind:   entity.PrincipalKindUser,
+		UserID: userID,
+	}, accessTokenExpiresAt)
+	if err != nil {
+		return nil, types.ID{}, errtrace.Errorf("issue access token: %w", err)
+	}
+
+	return &dto.AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiresAt,
+		RefreshToken:          secret,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+	}, refreshToken.ID, nil
+}
+
+// verify checks the password and persists the resulting credentials state:
+// failed attempts and lockout, or a rehash with the current parameters.
+// Locked credentials are rejected like a wrong password, so the lockout does not reveal the account.
+func (s *Service) verify(ctx context.Context, credentials *entity.Credentials, password string) error {
+	now := time.Now()
+
+	if credentials.IsLocked(now) {
+		return s.reject(password)
+	}
+
+	match, needsRehash, err := s.passwordHasher.Verify(password, credentials.PasswordHash)
+	if err != nil {
+		return errtrace.Errorf("verify password: %w", err)
+	}
+
+	if !match {
+		credentials.RegisterFailedAttempt(now, s.lockoutPolicy)
+
+		if err := s.credentialsRepo.Save(ctx, credentials); err != nil {
+			return err
+		}
+
+		return port.ErrInvalidCredentials
+	}
+
+	changed := credentials.ResetFailedAttempts()
+
+	if needsRehash {
+		passwordHash, err := s.passwordHasher.Hash(password)
+		if err != nil {
+			return errtrace.Errorf("rehash password: %w", err)
+		}
+
+		credentials.PasswordHash = passwordHash
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return s.credentialsRepo.Save(ctx, credentials)
+}
+
+// reject verifies the password against the dummy hash, taking as long as a wrong password.
+func (s *Service) reject(password string) error {
+	dummyHash, err := s.dummyHash()
+	if err != nil {
+		return errtrace.Errorf("hash dummy password: %w", err)
+	}
+
+	_, _, _ = s.passwordHasher.Verify(password, dummyHash)
+
+	return port.ErrInvalidCredentials
+}
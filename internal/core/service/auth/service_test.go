@@ -0,0 +1,436 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/mocks"
+	"app/pkg/transactor/transactortest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type authMocks struct {
+	users  *mocks.MockUserRepository
+	creds  *mocks.MockCredentialsRepository
+	tokens *mocks.MockRefreshTokenRepository
+	hasher *mocks.MockPasswordHasher
+	issuer *mocks.MockAccessTokenIssuer
+}
+
+func newAuthMocks(ctrl *gomock.Controller) authMocks {
+	return authMocks{
+		users:  mocks.NewMockUserRepository(ctrl),
+		creds:  mocks.NewMockCredentialsRepository(ctrl),
+		tokens: mocks.NewMockRefreshTokenRepository(ctrl),
+		hasher: mocks.NewMockPasswordHasher(ctrl),
+		issuer: mocks.NewMockAccessTokenIssuer(ctrl),
+	}
+}
+
+func (m authMocks) newService(lockoutPolicy entity.LockoutPolicy, tokenPolicy entity.TokenPolicy) *Service {
+	return NewService(
+		lockoutPolicy, tokenPolicy, m.users, m.creds, m.tokens, m.hasher, m.issuer, transactortest.PassThrough{},
+	)
+}
+
+// expectIssue expects a new token pair to be issued for the user.
+func (m authMocks) expectIssue(ctx context.Context, userID uuid.UUID) *gomock.Call {
+	m.tokens.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, token *entity.RefreshToken) error {
+			if token.UserID != userID {
+				return errors.New("unexpected user")
+			}
+
+			return nil
+		},
+	).Times(1)
+
+	return m.issuer.EXPECT().
+		Issue(&entity.Principal{Kind: entity.PrincipalKindUser, UserID: userID}, gomock.Any()).
+		Return("access-token", nil).
+		Times(1)
+}
+
+func TestAuthService_Login(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	lockoutPolicy := entity.LockoutPolicy{MaxFailedAttempts: 3, Duration: time.Minute}
+	tokenPolicy := entity.TokenPolicy{AccessTTL: time.Minute, RefreshTTL: time.Hour}
+	mockUser := &entity.User{ID: uuid.New(), Username: "alice"}
+	lockedUntil := time.Now().Add(time.Minute)
+	errRepoFailed := errors.New("repository failed")
+
+	newCredentials := func(failedAttempts int, lockedUntil *time.Time) *entity.Credentials {
+		return &entity.Credentials{
+			UserID:         mockUser.ID,
+			PasswordHash:   "stored-hash",
+			FailedAttempts: failedAttempts,
+			LockedUntil:    lockedUntil,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		setupMock   func(m authMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setupMock: func(m authMocks) {
+				m.users.EXPECT().GetByUsername(ctx, "alice").Return(mockUser, nil).Times(1)
+				m.creds.EXPECT().GetForUpdate(ctx, mockUser.ID).Return(newCredentials(0, nil), nil).Times(1)
+				m.hasher.EXPECT().Verify("password", "stored-hash").Return(true, false, nil).Times(1)
+				m.expectIssue(ctx, mockUser.ID)
+			},
+		},
+		{
+			name: "Success Resets Failed Attempts And Rehashes",
+			setupMock: func(m authMocks) {
+				m.users.EXPECT().GetByUsername(ctx, "alice").Return(mockUser, nil).Times(1)
+				m.creds.EXPECT().GetForUpdate(ctx, mockUser.ID).Return(newCredentials(2, nil), nil).Times(1)
+				m.hasher.EXPECT().Verify("password", "stored-hash").Return(true, true, nil).Times(1)
+				m.hasher.EXPECT().Hash("password").Return("new-hash", nil).Times(1)
+				m.creds.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, credentials *entity.Credentials) error {
+						assert.Equal(t, "new-hash", credentials.PasswordHash)
+						assert.Zero(t, credentials.FailedAttempts)
+
+						return nil
+					},
+				).Times(1)
+				m.expectIssue(ctx, mockUser.ID)
+			},
+		},
+		{
+			name: "Unknown User",
+			setupMock: func(m authMocks) {
+				m.users.EXPECT().GetByUsername(ctx, "alice").Return(nil, port.ErrUserNotFound).Times(1)
+				m.hasher.EXPECT().Hash(gomock.Any()).Return("dummy-hash", nil).Times(1)
+				m.hasher.EXPECT().Verify("password", "dummy-hash").Return(false, false, nil).Times(1)
+			},
+			expectedErr: port.ErrInvalidCredentials,
+		},
+		{
+			name: "User Without Credentials",
+			setupMock: func(m authMocks) {
+				m.users.EXPECT().GetByUsername(ctx, "alice").Return(mockUser, nil).Times(1)
+				m.creds.EXPECT().GetForUpdate(ctx, mockUser.ID).Return(nil, port.ErrCredentialsNotFound).Times(1)
+				m.hasher.EXPECT().Hash(gomock.Any()).Return("dummy-hash", nil).Times(1)
+				m.hasher.EXPECT().Verify("password", "dummy-hash").Return(false, false, nil).Times(1)
+			},
+			expectedErr: port.ErrInvalidCredentials,
+		},
+		{
+			name: "Wrong Password Counts Attempt",
+			setupMock: func(m authMocks) {
+				m.users.EXPECT().GetByUsername(ctx, "alice").Return(mockUser, nil).Times(1)
+				m.creds.EXPECT().GetForUpdate(ctx, mockUser.ID).Return(newCredentials(0, nil), nil).Times(1)
+				m.hasher.EXPECT().Verify("password", "stored-hash").Return(false, false, nil).Times(1)
+				m.creds.EXPECT().Save(ctx, newCredentials(1, nil)).Return(nil).Times(1)
+			},
+			expectedErr: port.ErrInvalidCredentials,
+		},
+		{
+			name: "Wrong Password Locks",
+			setupMock: func(m authMocks) {
+				m.users.EXPECT().GetByUsername(ctx, "alice").Return(mockUser, nil).Times(1)
+				m.creds.EXPECT().GetForUpdate(ctx, mockUser.ID).Return(newCredentials(2, nil), nil).Times(1)
+				m.hasher.EXPECT().Verify("password", "stored-hash").Return(false, false, nil).Times(1)
+				m.creds.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, credentials *entity.Credentials) error {
+						assert.Equal(t, 3, credentials.FailedAttempts)
+						assert.NotNil(t, credentials.LockedUntil)
+
+						return nil
+					},
+				).Times(1)
+			},
+			expectedErr: port.ErrInvalidCredentials,
+		},
+		{
+			name: "Locked Like Wrong Password",
+			setupMock: func(m authMocks) {
+				m.users.EXPECT().GetByUsername(ctx, "alice").Return(mockUser, nil).Times(1)
+				m.creds.EXPECT().GetForUpdate(ctx, mockUser.ID).Return(newCredentials(3, &lockedUntil), nil).Times(1)
+				m.hasher.EXPECT().Hash(gomock.Any()).Return("dummy-hash", nil).Times(1)
+				m.hasher.EXPECT().Verify("password", "dummy-hash").Return(false, false, nil).Times(1)
+			},
+			expectedErr: port.ErrInvalidCredentials,
+		},
+		{
+			name: "Repo Error",
+			setupMock: func(m authMocks) {
+				m.users.EXPECT().GetByUsername(ctx, "alice").Return(mockUser, nil).Times(1)
+				m.creds.EXPECT().GetForUpdate(ctx, mockUser.ID).Return(nil, errRepoFailed).Times(1)
+			},
+			expectedErr: errRepoFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAuthMocks(ctrl)
+			tc.setupMock(m)
+
+			service := m.newService(lockoutPolicy, tokenPolicy)
+
+			tokens, err := service.Login(ctx, dto.Login{Username: " alice ", Password: "password"})
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, tokens)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "access-token", tokens.AccessToken)
+				assert.NotEmpty(t, tokens.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tokenPolicy := entity.TokenPolicy{AccessTTL: time.Minute, RefreshTTL: time.Hour}
+	userID := uuid.New()
+	errRepoFailed := errors.New("repository failed")
+	revokedAt := time.Now()
+	replacedBy := uuid.New()
+
+	newToken := func(modify func(token *entity.RefreshToken)) *entity.RefreshToken {
+		token := &entity.RefreshToken{
+			ID:        uuid.New(),
+			UserID:    userID,
+			FamilyID:  uuid.New(),
+			TokenHash: entity.HashTokenSecret("secret"),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		if modify != nil {
+			modify(token)
+		}
+
+		return token
+	}
+
+	testCases := []struct {
+		name        string
+		setupMock   func(m authMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success Rotates Token",
+			setupMock: func(m authMocks) {
+				current := newToken(nil)
+
+				m.tokens.EXPECT().GetByHashForUpdate(ctx, current.TokenHash).Return(current, nil).Times(1)
+				m.tokens.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, token *entity.RefreshToken) error {
+						assert.Equal(t, current.FamilyID, token.FamilyID)
+						assert.NotEqual(t, current.TokenHash, token.TokenHash)
+
+						return nil
+					},
+				).Times(1)
+				m.issuer.EXPECT().Issue(gomock.Any(), gomock.Any()).Return("access-token", nil).Times(1)
+				m.tokens.EXPECT().Revoke(ctx, current.ID, gomock.Not(gomock.Nil())).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Unknown Token",
+			setupMock: func(m authMocks) {
+				m.tokens.EXPECT().GetByHashForUpdate(ctx, gomock.Any()).
+					Return(nil, port.ErrRefreshTokenNotFound).Times(1)
+			},
+			expectedErr: port.ErrInvalidToken,
+		},
+		{
+			name: "Reused Token Revokes Family",
+			setupMock: func(m authMocks) {
+				current := newToken(func(token *entity.RefreshToken) {
+					token.RevokedAt = &revokedAt
+					token.ReplacedBy = &replacedBy
+				})
+
+				m.tokens.EXPECT().GetByHashForUpdate(ctx, gomock.Any()).Return(current, nil).Times(1)
+				m.tokens.EXPECT().RevokeFamily(ctx, current.FamilyID).Return(nil).Times(1)
+			},
+			expectedErr: port.ErrRefreshTokenReused,
+		},
+		{
+			name: "Revoked Token",
+			setupMock: func(m authMocks) {
+				current := newToken(func(token *entity.RefreshToken) {
+					token.RevokedAt = &revokedAt
+				})
+
+				m.tokens.EXPECT().GetByHashForUpdate(ctx, gomock.Any()).Return(current, nil).Times(1)
+			},
+			expectedErr: port.ErrInvalidToken,
+		},
+		{
+			name: "Expired Token",
+			setupMock: func(m authMocks) {
+				current := newToken(func(token *entity.RefreshToken) {
+					token.ExpiresAt = time.Now().Add(-time.Second)
+				})
+
+				m.tokens.EXPECT().GetByHashForUpdate(ctx, gomock.Any()).Return(current, nil).Times(1)
+			},
+			expectedErr: port.ErrTokenExpired,
+		},
+		{
+			name: "Repo Error",
+			setupMock: func(m authMocks) {
+				m.tokens.EXPECT().GetByHashForUpdate(ctx, gomock.Any()).Return(nil, errRepoFailed).Times(1)
+			},
+			expectedErr: errRepoFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAuthMocks(ctrl)
+			tc.setupMock(m)
+
+			tokens, err := m.newService(entity.LockoutPolicy{}, tokenPolicy).Refresh(ctx, "secret")
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, tokens)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "access-token", tokens.AccessToken)
+				assert.NotEqual(t, "secret", tokens.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		setupMock   func(m authMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success Revokes Family",
+			setupMock: func(m authMocks) {
+				token := &entity.RefreshToken{ID: uuid.New(), FamilyID: uuid.New()}
+
+				m.tokens.EXPECT().GetByHashForUpdate(ctx, entity.HashTokenSecret("secret")).Return(token, nil).Times(1)
+				m.tokens.EXPECT().RevokeFamily(ctx, token.FamilyID).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Unknown Token",
+			setupMock: func(m authMocks) {
+				m.tokens.EXPECT().GetByHashForUpdate(ctx, gomock.Any()).
+					Return(nil, port.ErrRefreshTokenNotFound).Times(1)
+			},
+			expectedErr: port.ErrInvalidToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAuthMocks(ctrl)
+			tc.setupMock(m)
+
+			err := m.newService(entity.LockoutPolicy{}, entity.TokenPolicy{}).Logout(ctx, "secret")
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	principal := &entity.Principal{Kind: entity.PrincipalKindUser, UserID: uuid.New()}
+
+	testCases := []struct {
+		name              string
+		token             string
+		setupMock         func(m authMocks)
+		expectedPrincipal *entity.Principal
+		expectedErr       error
+	}{
+		{
+			name:  "Success",
+			token: "access-token",
+			setupMock: func(m authMocks) {
+				m.issuer.EXPECT().Verify("access-token").Return(principal, nil).Times(1)
+			},
+			expectedPrincipal: principal,
+		},
+		{
+			name:        "Missing Token",
+			setupMock:   func(authMocks) {},
+			expectedErr: port.ErrUnauthenticated,
+		},
+		{
+			name:  "Expired Token",
+			token: "access-token",
+			setupMock: func(m authMocks) {
+				m.issuer.EXPECT().Verify("access-token").Return(nil, port.ErrTokenExpired).Times(1)
+			},
+			expectedErr: port.ErrTokenExpired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAuthMocks(ctrl)
+			tc.setupMock(m)
+
+			principal, err := m.newService(entity.LockoutPolicy{}, entity.TokenPolicy{}).Authenticate(ctx, tc.token)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedPrincipal, principal)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package authorization
+
+import (
+	"context"
+
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/core/port"
+)
+
+type Service struct {
+	permissionRepo port.PermissionRepository
+}
+
+func NewService(permissionRepo port.PermissionRepository) *Service {
+	return &Service{permissionRepo: permissionRepo}
+}
+
+func (s *Service) Authorize(ctx context.Context, permission entity.Permission) error {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if !ok {
+		return port.ErrUnauthenticated
+	}
+
+	if principal.Permissions == nil {
+		permissions, err := s.permissionRepo.ListByUserID(ctx, principal.UserID)
+		if err != nil {
+			return err
+		}
+
+		principal.Permissions = permissions
+	}
+
+	granted := principal.Permissions.Has(permission)
+	if granted && len(principal.Scopes) > 0 {
+		granted = principal.HasScope(string(permission))
+	}
+
+	if !granted {
+		return port.ErrForbidden.With(domainErrors.Arg("permission", permission))
+	}
+
+	return nil
+}
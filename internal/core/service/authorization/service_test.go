@@ -0,0 +1,105 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/core/port"
+	"app/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorizationService_Authorize(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	errRepoFailed := errors.New("repository failed")
+	granted := entity.PermissionSet{entity.PermissionUsersRead}
+
+	testCases := []struct {
+		name        string
+		principal   *entity.Principal
+		setupMock   func(m *mocks.MockPermissionRepository)
+		expectedErr error
+	}{
+		{
+			name:      "Granted",
+			principal: &entity.Principal{UserID: userID},
+			setupMock: func(m *mocks.MockPermissionRepository) {
+				m.EXPECT().ListByUserID(gomock.Any(), userID).Return(granted, nil).Times(1)
+			},
+		},
+		{
+			name:      "Cached Permissions",
+			principal: &entity.Principal{UserID: userID, Permissions: granted},
+			setupMock: func(*mocks.MockPermissionRepository) {},
+		},
+		{
+			name:      "Not Granted",
+			principal: &entity.Principal{UserID: userID},
+			setupMock: func(m *mocks.MockPermissionRepository) {
+				m.EXPECT().ListByUserID(gomock.Any(), userID).Return(entity.PermissionSet{}, nil).Times(1)
+			},
+			expectedErr: port.ErrForbidden,
+		},
+		{
+			name:        "Outside Scopes",
+			principal:   &entity.Principal{UserID: userID, Scopes: []string{"users:export"}, Permissions: granted},
+			setupMock:   func(*mocks.MockPermissionRepository) {},
+			expectedErr: port.ErrForbidden,
+		},
+		{
+			name:        "No Principal",
+			setupMock:   func(*mocks.MockPermissionRepository) {},
+			expectedErr: port.ErrUnauthenticated,
+		},
+		{
+			name:      "Repo Error",
+			principal: &entity.Principal{UserID: userID},
+			setupMock: func(m *mocks.MockPermissionRepository) {
+				m.EXPECT().ListByUserID(gomock.Any(), userID).Return(nil, errRepoFailed).Times(1)
+			},
+			expectedErr: errRepoFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPermissionRepo := mocks.NewMockPermissionRepository(ctrl)
+			tc.setupMock(mockPermissionRepo)
+
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = entity.ContextWithPrincipal(ctx, tc.principal)
+			}
+
+			err := NewService(mockPermissionRepo).Authorize(ctx, entity.PermissionUsersRead)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if errors.Is(err, port.ErrForbidden) {
+				domainErr, ok := errors.AsType[*domainErrors.DomainError](err)
+				require.True(t, ok)
+				assert.Equal(t, []domainErrors.DomainErrorArg{
+					domainErrors.Arg("permission", entity.PermissionUsersRead),
+				}, domainErr.Args())
+			}
+		})
+	}
+}
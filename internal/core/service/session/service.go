@@ -0,0 +1,123 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/types"
+)
+
+type Service struct {
+	sessionRepo port.SessionRepository
+	authService port.AuthService
+	authorizer  port.Authorizer
+	policy      entity.SessionPolicy
+}
+
+func NewService(
+	policy entity.SessionPolicy,
+	sessionRepo port.SessionRepository,
+	authService port.AuthService,
+	authorizer port.Authorizer,
+) *Service {
+	return &Service{
+		sessionRepo: sessionRepo,
+		authService: authService,
+		authorizer:  authorizer,
+		policy:      policy,
+	}
+}
+
+func (s *Service) Start(ctx context.Context, input dto.StartSession) (*dto.CreatedSession, error) {
+	user, err := s.authService.VerifyCredentials(ctx, input.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	session, token, err := entity.NewSession(user.ID, input.UserAgent, input.IP, s.policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreatedSession{Session: session, Token: token}, nil
+}
+
+func (s *Service) Authenticate(ctx context.Context, token string) (*entity.Session, error) {
+	if token == "" {
+		return nil, port.ErrUnauthenticated
+	}
+
+	session, err := s.sessionRepo.GetByTokenHash(ctx, entity.HashTokenSecret(token))
+	if err != nil {
+		if errors.Is(err, port.ErrSessionNotFound) {
+			return nil, port.ErrInvalidSession
+		}
+
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if session.IsExpired(now) {
+		return nil, port.ErrSessionExpired
+	}
+
+	if session.Touch(now, s.policy) {
+		if err := s.sessionRepo.Touch(ctx, session); err != nil {
+			return nil, err
+		}
+	}
+
+	return session, nil
+}
+
+func (s *Service) End(ctx context.Context, token string) error {
+	return s.sessionRepo.DeleteByTokenHash(ctx, entity.HashTokenSecret(token))
+}
+
+func (s *Service) List(ctx context.Context, userID types.ID) ([]*entity.Session, error) {
+	if err := s.authorizeManage(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.sessionRepo.ListByUserID(ctx, userID, time.Now())
+}
+
+func (s *Service) Revoke(ctx context.Context, userID, sessionID types.ID) error {
+	if err := s.authorizeManage(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.Delete(ctx, userID, sessionID)
+}
+
+func (s *Service) RevokeAll(ctx context.Context, userID types.ID) error {
+	if err := s.authorizeManage(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.DeleteByUserID(ctx, userID)
+}
+
+func (s *Service) DeleteExpired(ctx context.Context) (int64, error) {
+	return s.sessionRepo.DeleteExpired(ctx, time.Now())
+}
+
+// authorizeManage lets users manage their own sessions. API keys acting on behalf
+// of a user need the permission like anyone else.
+func (s *Service) authorizeManage(ctx context.Context, userID types.ID) error {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if ok && principal.Kind == entity.PrincipalKindUser && principal.UserID == userID {
+		return nil
+	}
+
+	return s.authorizer.Authorize(ctx, entity.PermissionSessionsManage)
+}
@@ -0,0 +1,286 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testPolicy = entity.SessionPolicy{
+	IdleTimeout:   30 * time.Minute,
+	MaxLifetime:   12 * time.Hour,
+	TouchInterval: time.Minute,
+}
+
+type sessionMocks struct {
+	sessions   *mocks.MockSessionRepository
+	auth       *mocks.MockAuthService
+	authorizer *mocks.MockAuthorizer
+}
+
+func newSessionMocks(ctrl *gomock.Controller) sessionMocks {
+	return sessionMocks{
+		sessions:   mocks.NewMockSessionRepository(ctrl),
+		auth:       mocks.NewMockAuthService(ctrl),
+		authorizer: mocks.NewMockAuthorizer(ctrl),
+	}
+}
+
+func (m sessionMocks) newService() *Service {
+	return NewService(testPolicy, m.sessions, m.auth, m.authorizer)
+}
+
+func TestSessionService_Start(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockUser := &entity.User{ID: uuid.New(), Username: "alice"}
+	input := dto.StartSession{
+		Login:     dto.Login{Username: "alice", Password: "password"},
+		UserAgent: "agent",
+		IP:        "127.0.0.1",
+	}
+
+	testCases := []struct {
+		name        string
+		setupMock   func(m sessionMocks)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setupMock: func(m sessionMocks) {
+				m.auth.EXPECT().VerifyCredentials(ctx, input.Login).Return(mockUser, nil).Times(1)
+				m.sessions.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, session *entity.Session) error {
+						assert.Equal(t, mockUser.ID, session.UserID)
+						assert.Equal(t, "agent", session.UserAgent)
+
+						return nil
+					},
+				).Times(1)
+			},
+		},
+		{
+			name: "Invalid Credentials",
+			setupMock: func(m sessionMocks) {
+				m.auth.EXPECT().VerifyCredentials(ctx, input.Login).Return(nil, port.ErrInvalidCredentials).Times(1)
+			},
+			expectedErr: port.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newSessionMocks(ctrl)
+			tc.setupMock(m)
+
+			created, err := m.newService().Start(ctx, input)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, created)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, entity.HashTokenSecret(created.Token), created.Session.TokenHash)
+			}
+		})
+	}
+}
+
+func TestSessionService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errRepoFailed := errors.New("repository failed")
+
+	newSession := func(lastSeen, expiresIn time.Duration) *entity.Session {
+		now := time.Now()
+
+		return &entity.Session{
+			ID:         uuid.New(),
+			UserID:     uuid.New(),
+			CreatedAt:  now.Add(-time.Hour),
+			LastSeenAt: now.Add(-lastSeen),
+			ExpiresAt:  now.Add(expiresIn),
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		token       string
+		setupMock   func(m sessionMocks)
+		expectedErr error
+	}{
+		{
+			name:  "Recently Seen",
+			token: "secret",
+			setupMock: func(m sessionMocks) {
+				m.sessions.EXPECT().GetByTokenHash(ctx, entity.HashTokenSecret("secret")).
+					Return(newSession(time.Second, time.Minute), nil).Times(1)
+			},
+		},
+		{
+			name:  "Slides Expiration",
+			token: "secret",
+			setupMock: func(m sessionMocks) {
+				session := newSession(5*time.Minute, time.Minute)
+
+				m.sessions.EXPECT().GetByTokenHash(ctx, gomock.Any()).Return(session, nil).Times(1)
+				m.sessions.EXPECT().Touch(ctx, session).DoAndReturn(
+					func(_ context.Context, session *entity.Session) error {
+						assert.WithinDuration(t, time.Now().Add(testPolicy.IdleTimeout), session.ExpiresAt, time.Second)
+
+						return nil
+					},
+				).Times(1)
+			},
+		},
+		{
+			name:        "Missing Token",
+			setupMock:   func(sessionMocks) {},
+			expectedErr: port.ErrUnauthenticated,
+		},
+		{
+			name:  "Unknown Token",
+			token: "secret",
+			setupMock: func(m sessionMocks) {
+				m.sessions.EXPECT().GetByTokenHash(ctx, gomock.Any()).Return(nil, port.ErrSessionNotFound).Times(1)
+			},
+			expectedErr: port.ErrInvalidSession,
+		},
+		{
+			name:  "Expired",
+			token: "secret",
+			setupMock: func(m sessionMocks) {
+				m.sessions.EXPECT().GetByTokenHash(ctx, gomock.Any()).
+					Return(newSession(time.Hour, -time.Second), nil).Times(1)
+			},
+			expectedErr: port.ErrSessionExpired,
+		},
+		{
+			name:  "Repo Error",
+			token: "secret",
+			setupMock: func(m sessionMocks) {
+				m.sessions.EXPECT().GetByTokenHash(ctx, gomock.Any()).Return(nil, errRepoFailed).Times(1)
+			},
+			expectedErr: errRepoFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newSessionMocks(ctrl)
+			tc.setupMock(m)
+
+			session, err := m.newService().Authenticate(ctx, tc.token)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, session)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, session)
+			}
+		})
+	}
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	t.Parallel()
+
+	userID, sessionID := uuid.New(), uuid.New()
+	ownerCtx := entity.ContextWithPrincipal(context.Background(),
+		&entity.Principal{Kind: entity.PrincipalKindUser, UserID: userID})
+	otherCtx := entity.ContextWithPrincipal(context.Background(),
+		&entity.Principal{Kind: entity.PrincipalKindUser, UserID: uuid.New()})
+	apiKeyCtx := entity.ContextWithPrincipal(context.Background(),
+		&entity.Principal{Kind: entity.PrincipalKindAPIKey, UserID: userID})
+
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		setupMock   func(m sessionMocks, ctx context.Context)
+		expectedErr error
+	}{
+		{
+			name: "Own Session",
+			ctx:  ownerCtx,
+			setupMock: func(m sessionMocks, ctx context.Context) {
+				m.sessions.EXPECT().Delete(ctx, userID, sessionID).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Other User With Permission",
+			ctx:  otherCtx,
+			setupMock: func(m sessionMocks, ctx context.Context) {
+				m.authorizer.EXPECT().Authorize(ctx, entity.PermissionSessionsManage).Return(nil).Times(1)
+				m.sessions.EXPECT().Delete(ctx, userID, sessionID).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Other User Without Permission",
+			ctx:  otherCtx,
+			setupMock: func(m sessionMocks, ctx context.Context) {
+				m.authorizer.EXPECT().Authorize(ctx, entity.PermissionSessionsManage).Return(port.ErrForbidden).Times(1)
+			},
+			expectedErr: port.ErrForbidden,
+		},
+		{
+			name: "API Key Of Owner",
+			ctx:  apiKeyCtx,
+			setupMock: func(m sessionMocks, ctx context.Context) {
+				m.authorizer.EXPECT().Authorize(ctx, entity.PermissionSessionsManage).Return(port.ErrForbidden).Times(1)
+			},
+			expectedErr: port.ErrForbidden,
+		},
+		{
+			name: "Not Found",
+			ctx:  ownerCtx,
+			setupMock: func(m sessionMocks, ctx context.Context) {
+				m.sessions.EXPECT().Delete(ctx, userID, sessionID).Return(port.ErrSessionNotFound).Times(1)
+			},
+			expectedErr: port.ErrSessionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newSessionMocks(ctrl)
+			tc.setupMock(m, tc.ctx)
+
+			err := m.newService().Revoke(tc.ctx, userID, sessionID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
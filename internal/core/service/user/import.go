@@ -0,0 +1,278 @@
+package user
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/core/port"
+	"app/internal/types"
+	"app/pkg/errtrace"
+)
+
+const (
+	importUsernameColumn = "username"
+	importMaxLineSize    = 1 << 20
+)
+
+type importRow struct {
+	line     int
+	username string
+	err      error
+}
+
+// Import validates every row of the upload with the same rules as Create and
+// stores the valid ones in a single transaction. Rows that fail validation or
+// collide with other rows or existing users are reported instead of aborting the import.
+// Uploads beyond the size or the rows of the import policy are rejected as a whole.
+func (s *Service) Import(ctx context.Context, input dto.ImportUsers) (*dto.ImportUsersReport, error) {
+	if err := s.authorizer.Authorize(ctx, entity.PermissionUsersImport); err != nil {
+		return nil, err
+	}
+
+	if s.importPolicy.MaxSize > 0 {
+		input.Source = &sizeLimitReader{reader: input.Source, remaining: s.importPolicy.MaxSize}
+	}
+
+	rows, err := readImportRows(input, s.importPolicy.MaxRows)
+	if errors.Is(err, errImportTooLarge) {
+		return nil, port.ErrImportTooLarge.With(domainErrors.Arg("max_size", s.importPolicy.MaxSize))
+	} else if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ImportUsersReport{Total: len(rows)}
+
+	var (
+		users = make([]*entity.User, 0, len(rows))
+		lines = make(map[types.ID]importRow, len(rows))
+		seen  = make(map[string]struct{}, len(rows))
+	)
+
+	for _, row := range rows {
+		if row.err != nil {
+			report.Rejected = append(report.Rejected, newImportRowError(row, row.err))
+
+			continue
+		}
+
+		user, err := entity.NewUser(row.username)
+		if err != nil {
+			report.Rejected = append(report.Rejected, newImportRowError(row, err))
+
+			continue
+		}
+
+		key := strings.ToLower(user.Username)
+		if _, ok := seen[key]; ok {
+			report.Duplicates = append(report.Duplicates, newImportRowError(row, port.ErrImportDuplicateInInput))
+
+			continue
+		}
+
+		seen[key] = struct{}{}
+		lines[user.ID] = row
+		users = append(users, user)
+	}
+
+	if len(users) == 0 {
+		return report, nil
+	}
+
+	var existing []*entity.User
+
+	err = s.transactor.Do(ctx, func(ctx context.Context) error {
+		existing, err = s.userRepo.CreateMany(ctx, users)
+
+		return err
+	})
+	if err != nil {
+		return nil, errtrace.Errorf("create users: %w", err)
+	}
+
+	for _, user := range existing {
+		report.Duplicates = append(report.Duplicates, newImportRowError(lines[user.ID], port.ErrUserAlreadyExists))
+	}
+
+	report.Imported = len(users) - len(existing)
+
+	return report, nil
+}
+
+func newImportRowError(row importRow, err error) dto.ImportUsersRowError {
+	return dto.ImportUsersRowError{
+		Line:     row.line,
+		Username: row.username,
+		Err:      err,
+	}
+}
+
+func readImportRows(input dto.ImportUsers, maxRows int) ([]importRow, error) {
+	switch input.Format {
+	case dto.ImportFormatCSV:
+		return readCSVRows(input.Source, maxRows)
+	case dto.ImportFormatNDJSON:
+		return readNDJSONRows(input.Source, maxRows)
+	default:
+		return nil, port.ErrUnsupportedImportFormat.With(
+			domainErrors.Arg("supported", []dto.ImportFormat{dto.ImportFormatCSV, dto.ImportFormatNDJSON}),
+		)
+	}
+}
+
+// errImportTooLarge is returned by the reader of an upload beyond the size limit.
+var errImportTooLarge = errors.New("import too large")
+
+// sizeLimitReader fails once more than the remaining bytes are read.
+type sizeLimitReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *sizeLimitReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, errImportTooLarge
+	}
+
+	// read one byte past the limit to tell an upload of the exact limit from a larger one
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+
+	n, err := r.reader.Read(p)
+
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, errImportTooLarge
+	}
+
+	return n, err
+}
+
+// checkRowCount fails once the rows exceed the maximum, zero meaning no limit.
+func checkRowCount(rows []importRow, maxRows int) error {
+	if maxRows > 0 && len(rows) > maxRows {
+		return port.ErrImportTooManyRows.With(domainErrors.Arg("max_rows", maxRows))
+	}
+
+	return nil
+}
+
+// readCSVRows expects a header line containing a username column.
+func readCSVRows(source io.Reader, maxRows int) ([]importRow, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, port.ErrImportMissingUsernameColumn
+		}
+
+		return nil, port.ErrImportMalformedRow.WrapErr(err)
+	}
+
+	column := -1
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")), importUsernameColumn) {
+			column = i
+
+			break
+		}
+	}
+
+	if column < 0 {
+		return nil, port.ErrImportMissingUsernameColumn
+	}
+
+	var rows []importRow
+
+	for {
+		if err := checkRowCount(rows, maxRows); err != nil {
+			return nil, err
+		}
+
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		if parseErr, ok := errors.AsType[*csv.ParseError](err); ok {
+			rows = append(rows, importRow{line: parseErr.StartLine, err: port.ErrImportMalformedRow.WrapErr(parseErr.Err)})
+
+			continue
+		} else if err != nil {
+			return nil, errtrace.Errorf("read csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if column >= len(record) {
+			rows = append(rows, importRow{line: line, err: port.ErrImportMalformedRow.Wrap("missing username value")})
+
+			continue
+		}
+
+		rows = append(rows, importRow{line: line, username: record[column]})
+	}
+}
+
+func readNDJSONRows(source io.Reader, maxRows int) ([]importRow, error) {
+	scanner := bufio.NewScanner(source)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), importMaxLineSize)
+
+	var (
+		rows []importRow
+		line int
+	)
+
+	for scanner.Scan() {
+		if err := checkRowCount(rows, maxRows); err != nil {
+			return nil, err
+		}
+
+		line++
+
+		data := strings.TrimSpace(scanner.Text())
+		if data == "" {
+			continue
+		}
+
+		var record struct {
+			Username *string `json:"username"`
+		}
+
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			rows = append(rows, importRow{line: line, err: port.ErrImportMalformedRow.WrapErr(err)})
+
+			continue
+		}
+
+		if record.Username == nil {
+			rows = append(rows, importRow{line: line, err: port.ErrImportMalformedRow.Wrap("missing username value")})
+
+			continue
+		}
+
+		rows = append(rows, importRow{line: line, username: *record.Username})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errtrace.Errorf("read ndjson: %w", err)
+	}
+
+	if err := checkRowCount(rows, maxRows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
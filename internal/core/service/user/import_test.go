@@ -0,0 +1,193 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/mocks"
+	"app/pkg/transactor/transactortest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserService_Import(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errRepoFailed := errors.New("repository failed")
+
+	testCases := []struct {
+		name               string
+		policy             entity.ImportPolicy
+		input              dto.ImportUsers
+		setupMock          func(m *mocks.MockUserRepository)
+		expectedErr        error
+		expectedImported   int
+		expectedRejected   map[int]error
+		expectedDuplicates map[int]error
+	}{
+		{
+			name: "CSV",
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatCSV,
+				Source: strings.NewReader("email,username\na@example.com,alice\nb@example.com,ab\nc@example.com,ALICE\nd@example.com,bob\n"),
+			},
+			setupMock: func(m *mocks.MockUserRepository) {
+				m.EXPECT().CreateMany(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, users []*entity.User) ([]*entity.User, error) {
+						require.Len(t, users, 2)
+						assert.Equal(t, "alice", users[0].Username)
+						assert.Equal(t, "bob", users[1].Username)
+
+						return []*entity.User{users[1]}, nil
+					},
+				).Times(1)
+			},
+			expectedImported:   1,
+			expectedRejected:   map[int]error{3: entity.ErrUsernameTooShort},
+			expectedDuplicates: map[int]error{4: port.ErrImportDuplicateInInput, 5: port.ErrUserAlreadyExists},
+		},
+		{
+			name: "NDJSON",
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatNDJSON,
+				Source: strings.NewReader("{\"username\":\"alice\"}\n\n{\"username\":\n{\"name\":\"bob\"}\n{\"username\":\"root\"}\n"),
+			},
+			setupMock: func(m *mocks.MockUserRepository) {
+				m.EXPECT().CreateMany(ctx, gomock.Len(1)).Return(nil, nil).Times(1)
+			},
+			expectedImported: 1,
+			expectedRejected: map[int]error{
+				3: port.ErrImportMalformedRow,
+				4: port.ErrImportMalformedRow,
+				5: entity.ErrUsernameReserved,
+			},
+		},
+		{
+			name: "Nothing Valid",
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatCSV,
+				Source: strings.NewReader("username\nx\n"),
+			},
+			setupMock:        func(m *mocks.MockUserRepository) {},
+			expectedRejected: map[int]error{2: entity.ErrUsernameTooShort},
+		},
+		{
+			name: "Missing Username Column",
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatCSV,
+				Source: strings.NewReader("email\na@example.com\n"),
+			},
+			setupMock:   func(m *mocks.MockUserRepository) {},
+			expectedErr: port.ErrImportMissingUsernameColumn,
+		},
+		{
+			name: "Unsupported Format",
+			input: dto.ImportUsers{
+				Format: "xml",
+				Source: strings.NewReader(""),
+			},
+			setupMock:   func(m *mocks.MockUserRepository) {},
+			expectedErr: port.ErrUnsupportedImportFormat,
+		},
+		{
+			name:   "Too Large",
+			policy: entity.ImportPolicy{MaxSize: 15},
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatCSV,
+				Source: strings.NewReader("username\nalice\nbob\n"),
+			},
+			setupMock:   func(m *mocks.MockUserRepository) {},
+			expectedErr: port.ErrImportTooLarge,
+		},
+		{
+			name:   "Size Limit Reached",
+			policy: entity.ImportPolicy{MaxSize: 19},
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatCSV,
+				Source: strings.NewReader("username\nalice\nbob\n"),
+			},
+			setupMock: func(m *mocks.MockUserRepository) {
+				m.EXPECT().CreateMany(ctx, gomock.Len(2)).Return(nil, nil).Times(1)
+			},
+			expectedImported: 2,
+		},
+		{
+			name:   "Too Many CSV Rows",
+			policy: entity.ImportPolicy{MaxRows: 1},
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatCSV,
+				Source: strings.NewReader("username\nalice\nbob\n"),
+			},
+			setupMock:   func(m *mocks.MockUserRepository) {},
+			expectedErr: port.ErrImportTooManyRows,
+		},
+		{
+			name:   "Too Many NDJSON Rows",
+			policy: entity.ImportPolicy{MaxRows: 1},
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatNDJSON,
+				Source: strings.NewReader("{\"username\":\"alice\"}\n{\"username\":\"bob\"}"),
+			},
+			setupMock:   func(m *mocks.MockUserRepository) {},
+			expectedErr: port.ErrImportTooManyRows,
+		},
+		{
+			name: "Repo Error",
+			input: dto.ImportUsers{
+				Format: dto.ImportFormatCSV,
+				Source: strings.NewReader("username\nalice\n"),
+			},
+			setupMock: func(m *mocks.MockUserRepository) {
+				m.EXPECT().CreateMany(ctx, gomock.Any()).Return(nil, errRepoFailed).Times(1)
+			},
+			expectedErr: errRepoFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			tc.setupMock(mockUserRepo)
+
+			service := NewService(tc.policy, mockUserRepo, nil, nil, stubAuthorizer{}, transactortest.PassThrough{})
+			report, err := service.Import(ctx, tc.input)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, report)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedImported, report.Imported)
+			assertImportRowErrors(t, tc.expectedRejected, report.Rejected)
+			assertImportRowErrors(t, tc.expectedDuplicates, report.Duplicates)
+		})
+	}
+}
+
+func assertImportRowErrors(t *testing.T, expected map[int]error, rows []dto.ImportUsersRowError) {
+	t.Helper()
+
+	require.Len(t, rows, len(expected))
+
+	for _, row := range rows {
+		expectedErr, ok := expected[row.Line]
+		require.True(t, ok, "unexpected row error on line %d: %v", row.Line, row.Err)
+		assert.ErrorIs(t, row.Err, expectedErr)
+	}
+}
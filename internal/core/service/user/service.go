@@ -5,28 +5,111 @@ import (
 
 	"app/internal/core/dto"
 	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
 	"app/internal/core/port"
 	"app/internal/types"
+	"app/pkg/errtrace"
+	"app/pkg/transactor"
 )
 
+// MaxListLimit caps the page size of List.
+const MaxListLimit = 100
+
 type Service struct {
-	userRepo port.UserRepository
+	importPolicy    entity.ImportPolicy
+	userRepo        port.UserRepository
+	credentialsRepo port.CredentialsRepository
+	passwordHasher  port.PasswordHasher
+	authorizer      port.Authorizer
+	transactor      transactor.Transactor
 }
 
-func NewService(userRepo port.UserRepository) *Service {
-	return &Service{userRepo: userRepo}
+func NewService(
+	importPolicy entity.ImportPolicy,
+	userRepo port.UserRepository,
+	credentialsRepo port.CredentialsRepository,
+	passwordHasher port.PasswordHasher,
+	authorizer port.Authorizer,
+	transactor transactor.Transactor,
+) *Service {
+	return &Service{
+		importPolicy:    importPolicy,
+		userRepo:        userRepo,
+		credentialsRepo: credentialsRepo,
+		passwordHasher:  passwordHasher,
+		authorizer:      authorizer,
+		transactor:      transactor,
+	}
 }
 
 func (s *Service) Create(ctx context.Context, input dto.CreateUser) (*entity.User, error) {
-	user := entity.NewUser(input.Username)
+	user, userErr := entity.NewUser(input.Username)
+
+	var passwordErr error
+	if input.Password != "" {
+		passwordErr = entity.ValidatePassword(input.Password)
+	}
+
+	if err := domainErrors.Join(userErr, passwordErr); err != nil {
+		return nil, err
+	}
+
+	if input.Password == "" {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+
+		return user, nil
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(input.Password)
+	if err != nil {
+		return nil, errtrace.Errorf("hash password: %w", err)
+	}
+
+	err = s.transactor.Do(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
 
-	if err := s.userRepo.Create(ctx, user); err != nil {
+		return s.credentialsRepo.Save(ctx, entity.NewCredentials(user.ID, passwordHash))
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
 
+// GetByID lets users read themselves without the users:read permission.
 func (s *Service) GetByID(ctx context.Context, id types.ID) (*entity.User, error) {
+	if principal, ok := entity.PrincipalFromContext(ctx); !ok || principal.UserID != id {
+		if err := s.authorizer.Authorize(ctx, entity.PermissionUsersRead); err != nil {
+			return nil, err
+		}
+	}
+
 	return s.userRepo.GetByID(ctx, id)
 }
+
+func (s *Service) List(ctx context.Context, input dto.ListUsers) ([]*entity.User, error) {
+	if err := s.authorizer.Authorize(ctx, entity.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	if input.Limit == 0 || input.Limit > MaxListLimit {
+		input.Limit = MaxListLimit
+	}
+
+	return s.userRepo.List(ctx, input)
+}
+
+func (s *Service) Export(ctx context.Context, filter dto.UserFilter, fn func(*entity.User) error) error {
+	if err := s.authorizer.Authorize(ctx, entity.PermissionUsersExport); err != nil {
+		return err
+	}
+
+	return s.transactor.Do(ctx, func(ctx context.Context) error {
+		return s.userRepo.Stream(ctx, filter, fn)
+	})
+}
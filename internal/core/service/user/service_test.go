@@ -7,31 +7,44 @@ import (
 
 	"app/internal/core/dto"
 	"app/internal/core/entity"
+	"app/internal/core/port"
 	"app/internal/mocks"
+	"app/pkg/transactor/transactortest"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
+// stubAuthorizer grants every permission unless err is set.
+type stubAuthorizer struct {
+	err error
+}
+
+func (a stubAuthorizer) Authorize(context.Context, entity.Permission) error {
+	return a.err
+}
+
 func TestUserService_Create(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
-	inputDTO := dto.CreateUser{Username: "testuser"}
+	username := "testuser"
 	errRepoFailed := errors.New("repository failed")
 
 	testCases := []struct {
 		name        string
+		input       dto.CreateUser
 		setupMock   func(m *mocks.MockUserRepository)
 		expectedErr error
 	}{
 		{
-			name: "Success",
+			name:  "Success",
+			input: dto.CreateUser{Username: username},
 			setupMock: func(m *mocks.MockUserRepository) {
 				m.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, user *entity.User) error {
-						assert.Equal(t, inputDTO.Username, user.Username)
+						assert.Equal(t, username, user.Username)
 						assert.NotEqual(t, uuid.Nil, user.ID)
 						return nil
 					},
@@ -40,12 +53,25 @@ func TestUserService_Create(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name: "Repo Error",
+			name:  "Repo Error",
+			input: dto.CreateUser{Username: username},
 			setupMock: func(m *mocks.MockUserRepository) {
 				m.EXPECT().Create(ctx, gomock.Any()).Return(errRepoFailed).Times(1)
 			},
 			expectedErr: errRepoFailed,
 		},
+		{
+			name:        "Invalid Username",
+			input:       dto.CreateUser{Username: "no"},
+			setupMock:   func(m *mocks.MockUserRepository) {},
+			expectedErr: entity.ErrUsernameTooShort,
+		},
+		{
+			name:        "Invalid Username And Password",
+			input:       dto.CreateUser{Username: "no", Password: "short"},
+			setupMock:   func(m *mocks.MockUserRepository) {},
+			expectedErr: entity.ErrPasswordTooShort,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -58,8 +84,81 @@ func TestUserService_Create(t *testing.T) {
 			mockUserRepo := mocks.NewMockUserRepository(ctrl)
 			tc.setupMock(mockUserRepo)
 
-			service := NewService(mockUserRepo)
-			user, err := service.Create(ctx, inputDTO)
+			service := NewService(entity.ImportPolicy{}, mockUserRepo, nil, nil, stubAuthorizer{}, transactortest.PassThrough{})
+			user, err := service.Create(ctx, tc.input)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, user)
+				assert.Equal(t, tc.input.Username, user.Username)
+			}
+		})
+	}
+}
+
+func TestUserService_CreateWithPassword(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	errCredentialsFailed := errors.New("credentials failed")
+
+	testCases := []struct {
+		name        string
+		password    string
+		setupMock   func(users *mocks.MockUserRepository, creds *mocks.MockCredentialsRepository, hasher *mocks.MockPasswordHasher)
+		expectedErr error
+	}{
+		{
+			name:     "Success",
+			password: "s3cret-password",
+			setupMock: func(users *mocks.MockUserRepository, creds *mocks.MockCredentialsRepository, hasher *mocks.MockPasswordHasher) {
+				hasher.EXPECT().Hash("s3cret-password").Return("hashed", nil).Times(1)
+				users.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(1)
+				creds.EXPECT().Save(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, credentials *entity.Credentials) error {
+						assert.Equal(t, "hashed", credentials.PasswordHash)
+						assert.NotEqual(t, uuid.Nil, credentials.UserID)
+
+						return nil
+					},
+				).Times(1)
+			},
+		},
+		{
+			name:        "Password Too Short",
+			password:    "short",
+			setupMock:   func(*mocks.MockUserRepository, *mocks.MockCredentialsRepository, *mocks.MockPasswordHasher) {},
+			expectedErr: entity.ErrPasswordTooShort,
+		},
+		{
+			name:     "Credentials Error",
+			password: "s3cret-password",
+			setupMock: func(users *mocks.MockUserRepository, creds *mocks.MockCredentialsRepository, hasher *mocks.MockPasswordHasher) {
+				hasher.EXPECT().Hash("s3cret-password").Return("hashed", nil).Times(1)
+				users.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(1)
+				creds.EXPECT().Save(ctx, gomock.Any()).Return(errCredentialsFailed).Times(1)
+			},
+			expectedErr: errCredentialsFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			mockCredentialsRepo := mocks.NewMockCredentialsRepository(ctrl)
+			mockHasher := mocks.NewMockPasswordHasher(ctrl)
+			tc.setupMock(mockUserRepo, mockCredentialsRepo, mockHasher)
+
+			service := NewService(entity.ImportPolicy{}, mockUserRepo, mockCredentialsRepo, mockHasher, stubAuthorizer{}, transactortest.PassThrough{})
+			user, err := service.Create(ctx, dto.CreateUser{Username: "testuser", Password: tc.password})
 
 			if tc.expectedErr != nil {
 				assert.ErrorIs(t, err, tc.expectedErr)
@@ -67,7 +166,6 @@ func TestUserService_Create(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, user)
-				assert.Equal(t, inputDTO.Username, user.Username)
 			}
 		})
 	}
@@ -86,7 +184,9 @@ func TestUserService_GetByID(t *testing.T) {
 
 	testCases := []struct {
 		name         string
+		ctx          context.Context
 		inputID      uuid.UUID
+		authorizer   stubAuthorizer
 		setupMock    func(m *mocks.MockUserRepository)
 		expectedUser *entity.User
 		expectedErr  error
@@ -109,6 +209,23 @@ func TestUserService_GetByID(t *testing.T) {
 			expectedUser: nil,
 			expectedErr:  errUserNotFound,
 		},
+		{
+			name:        "Forbidden",
+			inputID:     mockID,
+			authorizer:  stubAuthorizer{err: port.ErrForbidden},
+			setupMock:   func(*mocks.MockUserRepository) {},
+			expectedErr: port.ErrForbidden,
+		},
+		{
+			name:       "Self Without Permission",
+			ctx:        entity.ContextWithPrincipal(ctx, &entity.Principal{UserID: mockID}),
+			inputID:    mockID,
+			authorizer: stubAuthorizer{err: port.ErrForbidden},
+			setupMock: func(m *mocks.MockUserRepository) {
+				m.EXPECT().GetByID(gomock.Any(), mockID).Return(mockUser, nil).Times(1)
+			},
+			expectedUser: mockUser,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -121,8 +238,12 @@ func TestUserService_GetByID(t *testing.T) {
 			mockUserRepo := mocks.NewMockUserRepository(ctrl)
 			tc.setupMock(mockUserRepo)
 
-			service := NewService(mockUserRepo)
-			user, err := service.GetByID(ctx, tc.inputID)
+			if tc.ctx == nil {
+				tc.ctx = ctx
+			}
+
+			service := NewService(entity.ImportPolicy{}, mockUserRepo, nil, nil, tc.authorizer, transactortest.PassThrough{})
+			user, err := service.GetByID(tc.ctx, tc.inputID)
 
 			if tc.expectedErr != nil {
 				assert.ErrorIs(t, err, tc.expectedErr)
@@ -134,3 +255,100 @@ func TestUserService_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_List(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockUsers := []*entity.User{{ID: uuid.New(), Username: "listed"}}
+
+	testCases := []struct {
+		name          string
+		input         dto.ListUsers
+		expectedLimit uint64
+	}{
+		{
+			name:          "Default Limit",
+			input:         dto.ListUsers{},
+			expectedLimit: MaxListLimit,
+		},
+		{
+			name:          "Capped Limit",
+			input:         dto.ListUsers{Limit: MaxListLimit + 1},
+			expectedLimit: MaxListLimit,
+		},
+		{
+			name:          "Requested Limit",
+			input:         dto.ListUsers{Limit: 10, Offset: 5},
+			expectedLimit: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedInput := tc.input
+			expectedInput.Limit = tc.expectedLimit
+
+			mockUserRepo := mocks.NewMockUserRepository(ctrl)
+			mockUserRepo.EXPECT().List(ctx, expectedInput).Return(mockUsers, nil).Times(1)
+
+			service := NewService(entity.ImportPolicy{}, mockUserRepo, nil, nil, stubAuthorizer{}, transactortest.PassThrough{})
+			users, err := service.List(ctx, tc.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, mockUsers, users)
+		})
+	}
+}
+
+func TestUserService_Export(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	filter := dto.UserFilter{Username: "john"}
+	mockUser := &entity.User{ID: uuid.New(), Username: "john"}
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().Stream(ctx, filter, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ dto.UserFilter, fn func(*entity.User) error) error {
+			return fn(mockUser)
+		},
+	).Times(1)
+
+	var exported []*entity.User
+
+	service := NewService(entity.ImportPolicy{}, mockUserRepo, nil, nil, stubAuthorizer{}, transactortest.PassThrough{})
+	err := service.Export(ctx, filter, func(user *entity.User) error {
+		exported = append(exported, user)
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.User{mockUser}, exported)
+}
+
+func TestUserService_ExportForbidden(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewService(entity.ImportPolicy{}, mocks.NewMockUserRepository(ctrl), nil, nil,
+		stubAuthorizer{err: port.ErrForbidden}, transactortest.PassThrough{})
+	err := service.Export(context.Background(), dto.UserFilter{}, func(*entity.User) error {
+		t.Fatal("no user must be exported")
+
+		return nil
+	})
+
+	assert.ErrorIs(t, err, port.ErrForbidden)
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/types"
+	"app/pkg/errtrace"
+	pgxTransactor "app/pkg/transactor/pgx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type APIKeyRepository struct {
+	dbGetter pgxTransactor.DBGetter
+}
+
+func NewAPIKeyRepository(dbGetter pgxTransactor.DBGetter) *APIKeyRepository {
+	return &APIKeyRepository{dbGetter: dbGetter}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *entity.APIKey) error {
+	sql, args, err := psql.
+		Insert("api_keys").
+		Columns("id", "user_id", "name", "prefix", "secret_hash", "scopes", "expires_at").
+		Values(key.ID, key.UserID, key.Name, key.Prefix, key.SecretHash, key.Scopes, key.ExpiresAt).
+		Suffix("RETURNING created_at").
+		ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	if err := r.dbGetter(ctx).QueryRow(ctx, sql, args...).Scan(&key.CreatedAt); err != nil {
+		return errtrace.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	sql, args, err := psql.
+		Select(
+			"id", "user_id", "name", "prefix", "secret_hash", "scopes",
+			"expires_at", "last_used_at", "created_at", "revoked_at",
+		).
+		From("api_keys").
+		Where(sq.Eq{"prefix": prefix}).
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	key := &entity.APIKey{}
+
+	err = r.dbGetter(ctx).QueryRow(ctx, sql, args...).Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.SecretHash,
+		&key.Scopes,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.CreatedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, port.ErrAPIKeyNotFound
+		}
+
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, usage map[types.ID]time.Time) error {
+	ids := make([]types.ID, 0, len(usage))
+	usedAt := make([]time.Time, 0, len(usage))
+
+	for id, at := range usage {
+		ids = append(ids, id)
+		usedAt = append(usedAt, at)
+	}
+
+	sql, args, err := psql.
+		Update("api_keys").
+		Set("last_used_at", sq.Expr("usage.used_at")).
+		FromSelect(sq.
+			Select().
+			Column("unnest(?::uuid[]) AS id", ids).
+			Column("unnest(?::timestamptz[]) AS used_at", usedAt), "usage").
+		Where("api_keys.id = usage.id").
+		Where(sq.Or{
+			sq.Eq{"api_keys.last_used_at": nil},
+			sq.Expr("api_keys.last_used_at < usage.used_at"),
+		}).
+		ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	if _, err := r.dbGetter(ctx).Exec(ctx, sql, args...); err != nil {
+		return errtrace.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
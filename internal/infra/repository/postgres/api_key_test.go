@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/types"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	key, _, err := entity.NewAPIKey(uuid.New(), "ci", []entity.Permission{entity.PermissionUsersRead}, nil)
+	require.NoError(t, err)
+
+	createdAt := time.Now()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewAPIKeyRepository(dbGetter)
+
+	mockPool.ExpectQuery(regexp.QuoteMeta(
+		"INSERT INTO api_keys (id,user_id,name,prefix,secret_hash,scopes,expires_at)",
+	)).
+		WithArgs(key.ID, key.UserID, "ci", key.Prefix, key.SecretHash, key.Scopes, key.ExpiresAt).
+		WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+
+	require.NoError(t, repo.Create(context.Background(), key))
+	assert.Equal(t, createdAt, key.CreatedAt)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_GetByPrefix(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour)
+	mockKey := &entity.APIKey{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		Name:       "ci",
+		Prefix:     "a1b2c3",
+		SecretHash: "hash",
+		Scopes:     []entity.Permission{entity.PermissionUsersRead},
+		ExpiresAt:  &expiresAt,
+		CreatedAt:  time.Now(),
+	}
+	genericErr := errors.New("something went wrong")
+	query := regexp.QuoteMeta("FROM api_keys WHERE prefix = $1")
+
+	testCases := []struct {
+		name        string
+		setupMock   func(mock pgxmock.PgxPoolIface)
+		expectedKey *entity.APIKey
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs("a1b2c3").
+					WillReturnRows(pgxmock.NewRows([]string{
+						"id", "user_id", "name", "prefix", "secret_hash", "scopes",
+						"expires_at", "last_used_at", "created_at", "revoked_at",
+					}).AddRow(
+						mockKey.ID, mockKey.UserID, "ci", "a1b2c3", "hash", mockKey.Scopes,
+						&expiresAt, nil, mockKey.CreatedAt, nil,
+					))
+			},
+			expectedKey: mockKey,
+		},
+		{
+			name: "Not Found",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs("a1b2c3").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectedErr: port.ErrAPIKeyNotFound,
+		},
+		{
+			name: "Generic DB Error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs("a1b2c3").
+					WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewAPIKeyRepository(dbGetter)
+
+			tc.setupMock(mockPool)
+
+			key, err := repo.GetByPrefix(context.Background(), "a1b2c3")
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedKey, key)
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAPIKeyRepository_TouchLastUsed(t *testing.T) {
+	t.Parallel()
+
+	id, usedAt := uuid.New(), time.Now()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewAPIKeyRepository(dbGetter)
+
+	mockPool.ExpectExec(regexp.QuoteMeta(
+		"UPDATE api_keys SET last_used_at = usage.used_at "+
+			"FROM (SELECT unnest($1::uuid[]) AS id, unnest($2::timestamptz[]) AS used_at) AS usage "+
+			"WHERE api_keys.id = usage.id "+
+			"AND (api_keys.last_used_at IS NULL OR api_keys.last_used_at < usage.used_at)",
+	)).
+		WithArgs([]types.ID{id}, []time.Time{usedAt}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, repo.TouchLastUsed(context.Background(), map[types.ID]time.Time{id: usedAt}))
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
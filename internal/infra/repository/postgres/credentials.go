@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/types"
+	"app/pkg/errtrace"
+	pgxTransactor "app/pkg/transactor/pgx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type CredentialsRepository struct {
+	dbGetter pgxTransactor.DBGetter
+}
+
+func NewCredentialsRepository(dbGetter pgxTransactor.DBGetter) *CredentialsRepository {
+	return &CredentialsRepository{dbGetter: dbGetter}
+}
+
+func (r *CredentialsRepository) GetForUpdate(ctx context.Context, userID types.ID) (*entity.Credentials, error) {
+	sql, args, err := psql.
+		Select("user_id", "password_hash", "failed_attempts", "locked_until", "updated_at").
+		From("user_credentials").
+		Where(sq.Eq{"user_id": userID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	credentials := &entity.Credentials{}
+
+	err = r.dbGetter(ctx).QueryRow(ctx, sql, args...).Scan(
+		&credentials.UserID,
+		&credentials.PasswordHash,
+		&credentials.FailedAttempts,
+		&credentials.LockedUntil,
+		&credentials.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, port.ErrCredentialsNotFound
+		}
+
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return credentials, nil
+}
+
+func (r *CredentialsRepository) Save(ctx context.Context, credentials *entity.Credentials) error {
+	sql, args, err := psql.
+		Insert("user_credentials").
+		Columns("user_id", "password_hash", "failed_attempts", "locked_until").
+		Values(credentials.UserID, credentials.PasswordHash, credentials.FailedAttempts, credentials.LockedUntil).
+		Suffix(`ON CONFLICT (user_id) DO UPDATE SET
+			password_hash = EXCLUDED.password_hash,
+			failed_attempts = EXCLUDED.failed_attempts,
+			locked_until = EXCLUDED.locked_until,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`).
+		ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	if err := r.dbGetter(ctx).QueryRow(ctx, sql, args...).Scan(&credentials.UpdatedAt); err != nil {
+		return errtrace.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialsRepository_GetForUpdate(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	lockedUntil := time.Now().Add(time.Minute)
+	mockCredentials := &entity.Credentials{
+		UserID:         userID,
+		PasswordHash:   "hash",
+		FailedAttempts: 5,
+		LockedUntil:    &lockedUntil,
+		UpdatedAt:      time.Now(),
+	}
+	genericErr := errors.New("something went wrong")
+
+	sql, args, err := psql.
+		Select("user_id", "password_hash", "failed_attempts", "locked_until", "updated_at").
+		From("user_credentials").
+		Where(sq.Eq{"user_id": userID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                string
+		setupMock           func(mock pgxmock.PgxPoolIface)
+		expectedCredentials *entity.Credentials
+		expectedErr         error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(regexp.QuoteMeta(sql)).
+					WithArgs(args...).
+					WillReturnRows(pgxmock.NewRows(
+						[]string{"user_id", "password_hash", "failed_attempts", "locked_until", "updated_at"},
+					).AddRow(userID, "hash", 5, &lockedUntil, mockCredentials.UpdatedAt))
+			},
+			expectedCredentials: mockCredentials,
+		},
+		{
+			name: "Not Found",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(regexp.QuoteMeta(sql)).
+					WithArgs(args...).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectedErr: port.ErrCredentialsNotFound,
+		},
+		{
+			name: "Generic DB Error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(regexp.QuoteMeta(sql)).
+					WithArgs(args...).
+					WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewCredentialsRepository(dbGetter)
+
+			tc.setupMock(mockPool)
+
+			credentials, err := repo.GetForUpdate(context.Background(), userID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedCredentials, credentials)
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCredentialsRepository_Save(t *testing.T) {
+	t.Parallel()
+
+	credentials := entity.NewCredentials(uuid.New(), "hash")
+	updatedAt := time.Now()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewCredentialsRepository(dbGetter)
+
+	mockPool.ExpectQuery(regexp.QuoteMeta("INSERT INTO user_credentials (user_id,password_hash,failed_attempts,locked_until)")).
+		WithArgs(credentials.UserID, "hash", 0, credentials.LockedUntil).
+		WillReturnRows(pgxmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+
+	require.NoError(t, repo.Save(context.Background(), credentials))
+	assert.Equal(t, updatedAt, credentials.UpdatedAt)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
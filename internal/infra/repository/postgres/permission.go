@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+
+	"app/internal/core/entity"
+	"app/internal/types"
+	"app/pkg/errtrace"
+	pgxTransactor "app/pkg/transactor/pgx"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+type PermissionRepository struct {
+	dbGetter pgxTransactor.DBGetter
+}
+
+func NewPermissionRepository(dbGetter pgxTransactor.DBGetter) *PermissionRepository {
+	return &PermissionRepository{dbGetter: dbGetter}
+}
+
+func (r *PermissionRepository) ListByUserID(ctx context.Context, userID types.ID) (entity.PermissionSet, error) {
+	sql, args, err := psql.
+		Select("DISTINCT role_permissions.permission").
+		From("role_permissions").
+		Join("roles ON roles.id = role_permissions.role_id").
+		LeftJoin("user_roles ON user_roles.role_id = roles.id AND user_roles.user_id = ?", userID).
+		Where(sq.Or{
+			sq.Eq{"roles.is_default": true},
+			sq.NotEq{"user_roles.user_id": nil},
+		}).
+		OrderBy("role_permissions.permission").
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	rows, err := r.dbGetter(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	defer rows.Close()
+
+	permissions := entity.PermissionSet{}
+
+	for rows.Next() {
+		var permission entity.Permission
+		if err := rows.Scan(&permission); err != nil {
+			return nil, errtrace.Errorf("scan permission: %w", err)
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"app/internal/core/entity"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionRepository_ListByUserID(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	genericErr := errors.New("something went wrong")
+	query := regexp.QuoteMeta(
+		"SELECT DISTINCT role_permissions.permission FROM role_permissions " +
+			"JOIN roles ON roles.id = role_permissions.role_id " +
+			"LEFT JOIN user_roles ON user_roles.role_id = roles.id AND user_roles.user_id = $1 " +
+			"WHERE (roles.is_default = $2 OR user_roles.user_id IS NOT NULL)",
+	)
+
+	testCases := []struct {
+		name                string
+		setupMock           func(mock pgxmock.PgxPoolIface)
+		expectedPermissions entity.PermissionSet
+		expectedErr         error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(userID, true).
+					WillReturnRows(pgxmock.NewRows([]string{"permission"}).
+						AddRow(entity.PermissionUsersExport).
+						AddRow(entity.PermissionUsersRead))
+			},
+			expectedPermissions: entity.PermissionSet{entity.PermissionUsersExport, entity.PermissionUsersRead},
+		},
+		{
+			name: "No Permissions",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(userID, true).
+					WillReturnRows(pgxmock.NewRows([]string{"permission"}))
+			},
+			expectedPermissions: entity.PermissionSet{},
+		},
+		{
+			name: "Generic DB Error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(userID, true).
+					WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewPermissionRepository(dbGetter)
+
+			tc.setupMock(mockPool)
+
+			permissions, err := repo.ListByUserID(context.Background(), userID)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedPermissions, permissions)
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package postgres
 
-import sq "github.com/Masterminds/squirrel"
+import (
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
 
 var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
+// likeEscaper escapes LIKE pattern wildcards in user input.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 const (
 	duplicateKeyErrorCode = "23505"
 )
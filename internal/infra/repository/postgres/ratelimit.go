@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"app/internal/core/port"
+	"app/pkg/errtrace"
+	"app/pkg/ratelimit"
+	pgxTransactor "app/pkg/transactor/pgx"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+var rateLimitColumns = []string{
+	"key", "tokens", "window_start", "count", "prev_count", "updated_at", "expires_at", "allowed",
+}
+
+// rateLimitExpired tells the keys to start over, as if they had been deleted. The updated_at of the
+// inserted row is the time of the request.
+var rateLimitExpired = sq.Expr("rate_limits.expires_at <= EXCLUDED.updated_at")
+
+// RateLimitRepository keeps the state of the keys in the rate_limits table, shared by the replicas.
+// A request is taken by a single upsert, which applies it to the locked row of its key.
+type RateLimitRepository struct {
+	dbGetter pgxTransactor.DBGetter
+}
+
+var _ port.RateLimitStore = (*RateLimitRepository)(nil)
+
+func NewRateLimitRepository(dbGetter pgxTransactor.DBGetter) *RateLimitRepository {
+	return &RateLimitRepository{dbGetter: dbGetter}
+}
+
+func (r *RateLimitRepository) Take(
+	ctx context.Context,
+	key string,
+	limit ratelimit.Limit,
+	now time.Time,
+) (ratelimit.Result, error) {
+	// a new key is inserted with the state of its first request
+	first, _ := limit.Take(ratelimit.State{}, now)
+
+	sql, args, err := psql.
+		Insert("rate_limits").
+		Columns(rateLimitColumns...).
+		Values(
+			key, first.Tokens, nullTime(first.WindowStart), first.Count, first.PrevCount, first.UpdatedAt,
+			now.Add(limit.TTL()), true,
+		).
+		SuffixExpr(rateLimitUpdate(limit, now)).
+		ToSql()
+	if err != nil {
+		return ratelimit.Result{}, errtrace.Errorf("make query: %w", err)
+	}
+
+	var (
+		state       ratelimit.State
+		windowStart *time.Time
+		allowed     bool
+	)
+
+	err = r.dbGetter(ctx).QueryRow(ctx, sql, args...).
+		Scan(&state.Tokens, &windowStart, &state.Count, &state.PrevCount, &state.UpdatedAt, &allowed)
+	if err != nil {
+		return ratelimit.Result{}, errtrace.Errorf("execute query: %w", err)
+	}
+
+	if windowStart != nil {
+		state.WindowStart = *windowStart
+	}
+
+	return limit.Result(state, allowed, now), nil
+}
+
+// rateLimitUpdate applies the request to the existing row of a key, as ratelimit.Limit.Take does.
+func rateLimitUpdate(limit ratelimit.Limit, now time.Time) sq.Sqlizer {
+	var tokens, count, prevCount, allowed sq.Sqlizer
+
+	if limit.Algorithm == ratelimit.SlidingWindow {
+		windowStart, weight := limit.Window(now)
+
+		// the counts of the current and previous windows before the request
+		prevCount = sq.Expr(`CASE
+			WHEN ? THEN 0
+			WHEN rate_limits.window_start = EXCLUDED.window_start THEN rate_limits.prev_count
+			WHEN rate_limits.window_start = ? THEN rate_limits.count
+			ELSE 0
+		END`, rateLimitExpired, windowStart.Add(-limit.Period))
+		current := sq.Expr(`CASE
+			WHEN ? THEN 0
+			WHEN rate_limits.window_start = EXCLUDED.window_start THEN rate_limits.count
+			ELSE 0
+		END`, rateLimitExpired)
+
+		allowed = sq.Expr(
+			"? * ?::double precision + ? + 1 <= ?::double precision", prevCount, weight, current, float64(limit.Requests),
+		)
+		tokens = sq.Expr("EXCLUDED.tokens")
+		count = sq.Expr("? + CASE WHEN ? THEN 1 ELSE 0 END", current, allowed)
+	} else {
+		capacity := float64(limit.Capacity())
+
+		// the tokens refilled since the last request
+		available := sq.Expr(`CASE
+			WHEN ? THEN ?::double precision
+			ELSE LEAST(?::double precision, rate_limits.tokens + GREATEST(
+				EXTRACT(EPOCH FROM EXCLUDED.updated_at - rate_limits.updated_at)::double precision, 0
+			) * ?::double precision)
+		END`, rateLimitExpired, capacity, capacity, float64(limit.Requests)/limit.Period.Seconds())
+
+		allowed = sq.Expr("? >= 1", available)
+		tokens = sq.Expr("? - CASE WHEN ? THEN 1 ELSE 0 END", available, allowed)
+		count = sq.Expr("EXCLUDED.count")
+		prevCount = sq.Expr("EXCLUDED.prev_count")
+	}
+
+	return sq.Expr(`ON CONFLICT (key) DO UPDATE SET
+			tokens = ?,
+			window_start = EXCLUDED.window_start,
+			count = ?,
+			prev_count = ?,
+			updated_at = EXCLUDED.updated_at,
+			expires_at = EXCLUDED.expires_at,
+			allowed = ?
+		RETURNING tokens, window_start, count, prev_count, updated_at, allowed`,
+		tokens, count, prevCount, allowed,
+	)
+}
+
+func (r *RateLimitRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	sql, args, err := psql.
+		Delete("rate_limits").
+		Where(sq.LtOrEq{"expires_at": now}).
+		ToSql()
+	if err != nil {
+		return 0, errtrace.Errorf("make query: %w", err)
+	}
+
+	tag, err := r.dbGetter(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
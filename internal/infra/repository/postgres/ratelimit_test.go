@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"app/pkg/ratelimit"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitRepository_Take(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 15, 0, 30, 0, time.UTC)
+	windowStart := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+	prevWindowStart := windowStart.Add(-time.Minute)
+	genericErr := errors.New("something went wrong")
+	query := regexp.QuoteMeta(
+		"INSERT INTO rate_limits (key,tokens,window_start,count,prev_count,updated_at,expires_at,allowed) " +
+			"VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT (key) DO UPDATE SET",
+	)
+	columns := []string{"tokens", "window_start", "count", "prev_count", "updated_at", "allowed"}
+
+	testCases := []struct {
+		name           string
+		algorithm      ratelimit.Algorithm
+		setupMock      func(mock pgxmock.PgxPoolIface)
+		expectedResult ratelimit.Result
+		expectedErr    error
+	}{
+		{
+			name:      "Token Bucket Allowed",
+			algorithm: ratelimit.TokenBucket,
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(
+						"ip:127.0.0.1", 2.0, (*time.Time)(nil), 0, 0, now, now.Add(time.Minute), true,
+						3.0, 3.0, 0.05, 3.0, 3.0, 0.05, 3.0, 3.0, 0.05,
+					).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow(1.5, nil, 0, 0, now, true))
+			},
+			expectedResult: ratelimit.Result{Allowed: true, Limit: 3, Remaining: 1, Reset: 30 * time.Second},
+		},
+		{
+			name:      "Token Bucket Exceeded",
+			algorithm: ratelimit.TokenBucket,
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(
+						"ip:127.0.0.1", 2.0, (*time.Time)(nil), 0, 0, now, now.Add(time.Minute), true,
+						3.0, 3.0, 0.05, 3.0, 3.0, 0.05, 3.0, 3.0, 0.05,
+					).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow(0.5, nil, 0, 0, now, false))
+			},
+			expectedResult: ratelimit.Result{
+				Limit: 3, Reset: 50 * time.Second, RetryAfter: 10 * time.Second,
+			},
+		},
+		{
+			name:      "Sliding Window Exceeded",
+			algorithm: ratelimit.SlidingWindow,
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(
+						"ip:127.0.0.1", 0.0, &windowStart, 1, 0, now, now.Add(2*time.Minute), true,
+						prevWindowStart, 0.5, 3.0, prevWindowStart, prevWindowStart, 0.5, 3.0,
+					).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow(0.0, &windowStart, 2, 2, now, false))
+			},
+			expectedResult: ratelimit.Result{
+				Limit: 3, Reset: 30 * time.Second, RetryAfter: 30 * time.Second,
+			},
+		},
+		{
+			name:      "Query Error",
+			algorithm: ratelimit.TokenBucket,
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs(
+						"ip:127.0.0.1", 2.0, (*time.Time)(nil), 0, 0, now, now.Add(time.Minute), true,
+						3.0, 3.0, 0.05, 3.0, 3.0, 0.05, 3.0, 3.0, 0.05,
+					).
+					WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewRateLimitRepository(dbGetter)
+
+			tc.setupMock(mockPool)
+
+			limit := ratelimit.Limit{Algorithm: tc.algorithm, Requests: 3, Period: time.Minute}
+
+			result, err := repo.Take(context.Background(), "ip:127.0.0.1", limit, now)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedResult, result)
+			}
+
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRateLimitRepository_DeleteExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewRateLimitRepository(dbGetter)
+
+	mockPool.ExpectExec(regexp.QuoteMeta("DELETE FROM rate_limits WHERE expires_at <= $1")).
+		WithArgs(now).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	deleted, err := repo.DeleteExpired(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/types"
+	"app/pkg/errtrace"
+	pgxTransactor "app/pkg/transactor/pgx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+type RefreshTokenRepository struct {
+	dbGetter pgxTransactor.DBGetter
+}
+
+func NewRefreshTokenRepository(dbGetter pgxTransactor.DBGetter) *RefreshTokenRepository {
+	return &RefreshTokenRepository{dbGetter: dbGetter}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	sql, args, err := psql.
+		Insert("refresh_tokens").
+		Columns("id", "user_id", "family_id", "token_hash", "expires_at").
+		Values(token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).
+		Suffix("RETURNING created_at").
+		ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	if err := r.dbGetter(ctx).QueryRow(ctx, sql, args...).Scan(&token.CreatedAt); err != nil {
+		return errtrace.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) GetByHashForUpdate(
+	ctx context.Context,
+	tokenHash string,
+) (*entity.RefreshToken, error) {
+	sql, args, err := psql.
+		Select("id", "user_id", "family_id", "token_hash", "expires_at", "created_at", "revoked_at", "replaced_by").
+		From("refresh_tokens").
+		Where(sq.Eq{"token_hash": tokenHash}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	token := &entity.RefreshToken{}
+
+	err = r.dbGetter(ctx).QueryRow(ctx, sql, args...).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.RevokedAt,
+		&token.ReplacedBy,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, port.ErrRefreshTokenNotFound
+		}
+
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return token, nil
+}
+
+func (r *RefreshTokenRepository) Revoke(ctx context.Context, id types.ID, replacedBy *types.ID) error {
+	sql, args, err := psql.
+		Update("refresh_tokens").
+		Set("revoked_at", sq.Expr("COALESCE(revoked_at, CURRENT_TIMESTAMP)")).
+		Set("replaced_by", replacedBy).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	if _, err := r.dbGetter(ctx).Exec(ctx, sql, args...); err != nil {
+		return errtrace.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID types.ID) error {
+	sql, args, err := psql.
+		Update("refresh_tokens").
+		Set("revoked_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"family_id": familyID, "revoked_at": nil}).
+		ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	if _, err := r.dbGetter(ctx).Exec(ctx, sql, args...); err != nil {
+		return errtrace.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	token, _, err := entity.NewRefreshToken(uuid.New(), uuid.Nil, time.Hour)
+	require.NoError(t, err)
+
+	createdAt := time.Now()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewRefreshTokenRepository(dbGetter)
+
+	mockPool.ExpectQuery(regexp.QuoteMeta("INSERT INTO refresh_tokens (id,user_id,family_id,token_hash,expires_at)")).
+		WithArgs(token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt).
+		WillReturnRows(pgxmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+
+	require.NoError(t, repo.Create(context.Background(), token))
+	assert.Equal(t, createdAt, token.CreatedAt)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepository_GetByHashForUpdate(t *testing.T) {
+	t.Parallel()
+
+	replacedBy := uuid.New()
+	revokedAt := time.Now()
+	mockToken := &entity.RefreshToken{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		FamilyID:   uuid.New(),
+		TokenHash:  "hash",
+		ExpiresAt:  time.Now().Add(time.Hour),
+		CreatedAt:  time.Now(),
+		RevokedAt:  &revokedAt,
+		ReplacedBy: &replacedBy,
+	}
+	genericErr := errors.New("something went wrong")
+	query := regexp.QuoteMeta("FROM refresh_tokens WHERE token_hash = $1 FOR UPDATE")
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock pgxmock.PgxPoolIface)
+		expectedToken *entity.RefreshToken
+		expectedErr   error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs("hash").
+					WillReturnRows(pgxmock.NewRows([]string{
+						"id", "user_id", "family_id", "token_hash", "expires_at", "created_at", "revoked_at", "replaced_by",
+					}).AddRow(
+						mockToken.ID, mockToken.UserID, mockToken.FamilyID, "hash",
+						mockToken.ExpiresAt, mockToken.CreatedAt, &revokedAt, &replacedBy,
+					))
+			},
+			expectedToken: mockToken,
+		},
+		{
+			name: "Not Found",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs("hash").
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectedErr: port.ErrRefreshTokenNotFound,
+		},
+		{
+			name: "Generic DB Error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).
+					WithArgs("hash").
+					WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewRefreshTokenRepository(dbGetter)
+
+			tc.setupMock(mockPool)
+
+			token, err := repo.GetByHashForUpdate(context.Background(), "hash")
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedToken, token)
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTokenRepository_Revoke(t *testing.T) {
+	t.Parallel()
+
+	id, replacedBy := uuid.New(), uuid.New()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewRefreshTokenRepository(dbGetter)
+
+	mockPool.ExpectExec(regexp.QuoteMeta(
+		"UPDATE refresh_tokens SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP), replaced_by = $1 WHERE id = $2",
+	)).
+		WithArgs(&replacedBy, id.String()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, repo.Revoke(context.Background(), id, &replacedBy))
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepository_RevokeFamily(t *testing.T) {
+	t.Parallel()
+
+	familyID := uuid.New()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewRefreshTokenRepository(dbGetter)
+
+	mockPool.ExpectExec(regexp.QuoteMeta(
+		"UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE family_id = $1 AND revoked_at IS NULL",
+	)).
+		WithArgs(familyID.String()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+
+	require.NoError(t, repo.RevokeFamily(context.Background(), familyID))
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
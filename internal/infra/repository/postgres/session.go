@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/types"
+	"app/pkg/errtrace"
+	pgxTransactor "app/pkg/transactor/pgx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+var sessionColumns = []string{
+	"id", "user_id", "token_hash", "csrf_token", "user_agent", "ip", "created_at", "last_seen_at", "expires_at",
+}
+
+type SessionRepository struct {
+	dbGetter pgxTransactor.DBGetter
+}
+
+func NewSessionRepository(dbGetter pgxTransactor.DBGetter) *SessionRepository {
+	return &SessionRepository{dbGetter: dbGetter}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	sql, args, err := psql.
+		Insert("sessions").
+		Columns(sessionColumns...).
+		Values(
+			session.ID, session.UserID, session.TokenHash, session.CSRFToken, session.UserAgent, session.IP,
+			session.CreatedAt, session.LastSeenAt, session.ExpiresAt,
+		).
+		ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	if _, err := r.dbGetter(ctx).Exec(ctx, sql, args...); err != nil {
+		return errtrace.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Session, error) {
+	sql, args, err := psql.
+		Select(sessionColumns...).
+		From("sessions").
+		Where(sq.Eq{"token_hash": tokenHash}).
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	return r.scanSession(r.dbGetter(ctx).QueryRow(ctx, sql, args...))
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, session *entity.Session) error {
+	sql, args, err := psql.
+		Update("sessions").
+		Set("last_seen_at", session.LastSeenAt).
+		Set("expires_at", session.ExpiresAt).
+		Where(sq.Eq{"id": session.ID}).
+		ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	if _, err := r.dbGetter(ctx).Exec(ctx, sql, args...); err != nil {
+		return errtrace.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) ListByUserID(
+	ctx context.Context,
+	userID types.ID,
+	now time.Time,
+) ([]*entity.Session, error) {
+	sql, args, err := psql.
+		Select(sessionColumns...).
+		From("sessions").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Gt{"expires_at": now}).
+		OrderBy("last_seen_at DESC").
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	rows, err := r.dbGetter(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*entity.Session{}
+
+	for rows.Next() {
+		session, err := r.scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, userID, id types.ID) error {
+	affected, err := r.delete(ctx, sq.Eq{"id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return port.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) DeleteByTokenHash(ctx context.Context, tokenHash string) error {
+	_, err := r.delete(ctx, sq.Eq{"token_hash": tokenHash})
+
+	return err
+}
+
+func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID types.ID) error {
+	_, err := r.delete(ctx, sq.Eq{"user_id": userID})
+
+	return err
+}
+
+func (r *SessionRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return r.delete(ctx, sq.LtOrEq{"expires_at": now})
+}
+
+func (r *SessionRepository) delete(ctx context.Context, where sq.Sqlizer) (int64, error) {
+	sql, args, err := psql.
+		Delete("sessions").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return 0, errtrace.Errorf("make query: %w", err)
+	}
+
+	tag, err := r.dbGetter(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *SessionRepository) scanSession(row pgx.Row) (*entity.Session, error) {
+	session := &entity.Session{}
+
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.TokenHash,
+		&session.CSRFToken,
+		&session.UserAgent,
+		&session.IP,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, port.ErrSessionNotFound
+		}
+
+		return nil, errtrace.Errorf("scan session: %w", err)
+	}
+
+	return session, nil
+}
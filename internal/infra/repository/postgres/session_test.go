@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSession() *entity.Session {
+	now := time.Now()
+
+	return &entity.Session{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		TokenHash:  "hash",
+		CSRFToken:  "csrf",
+		UserAgent:  "agent",
+		IP:         "127.0.0.1",
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+}
+
+func newSessionRows(sessions ...*entity.Session) *pgxmock.Rows {
+	rows := pgxmock.NewRows(sessionColumns)
+	for _, s := range sessions {
+		rows.AddRow(s.ID, s.UserID, s.TokenHash, s.CSRFToken, s.UserAgent, s.IP, s.CreatedAt, s.LastSeenAt, s.ExpiresAt)
+	}
+
+	return rows
+}
+
+func TestSessionRepository_GetByTokenHash(t *testing.T) {
+	t.Parallel()
+
+	mockSession := newTestSession()
+	genericErr := errors.New("something went wrong")
+	query := regexp.QuoteMeta("FROM sessions WHERE token_hash = $1")
+
+	testCases := []struct {
+		name            string
+		setupMock       func(mock pgxmock.PgxPoolIface)
+		expectedSession *entity.Session
+		expectedErr     error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs("hash").WillReturnRows(newSessionRows(mockSession))
+			},
+			expectedSession: mockSession,
+		},
+		{
+			name: "Not Found",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs("hash").WillReturnError(pgx.ErrNoRows)
+			},
+			expectedErr: port.ErrSessionNotFound,
+		},
+		{
+			name: "Generic DB Error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(query).WithArgs("hash").WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewSessionRepository(dbGetter)
+
+			tc.setupMock(mockPool)
+
+			session, err := repo.GetByTokenHash(context.Background(), "hash")
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedSession, session)
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRepository_ListByUserID(t *testing.T) {
+	t.Parallel()
+
+	mockSession := newTestSession()
+	now := time.Now()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewSessionRepository(dbGetter)
+
+	mockPool.ExpectQuery(regexp.QuoteMeta(
+		"FROM sessions WHERE user_id = $1 AND expires_at > $2 ORDER BY last_seen_at DESC",
+	)).
+		WithArgs(mockSession.UserID.String(), now).
+		WillReturnRows(newSessionRows(mockSession))
+
+	sessions, err := repo.ListByUserID(context.Background(), mockSession.UserID, now)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Session{mockSession}, sessions)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestSessionRepository_Delete(t *testing.T) {
+	t.Parallel()
+
+	userID, id := uuid.New(), uuid.New()
+	query := regexp.QuoteMeta("DELETE FROM sessions WHERE id = $1 AND user_id = $2")
+
+	testCases := []struct {
+		name        string
+		affected    int64
+		expectedErr error
+	}{
+		{name: "Deleted", affected: 1},
+		{name: "Not Found", expectedErr: port.ErrSessionNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewSessionRepository(dbGetter)
+
+			mockPool.ExpectExec(query).
+				WithArgs(id.String(), userID.String()).
+				WillReturnResult(pgxmock.NewResult("DELETE", tc.affected))
+
+			err := repo.Delete(context.Background(), userID, id)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSessionRepository_DeleteExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewSessionRepository(dbGetter)
+
+	mockPool.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE expires_at <= $1")).
+		WithArgs(now).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	deleted, err := repo.DeleteExpired(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"app/internal/core/dto"
 	"app/internal/core/entity"
 	"app/internal/core/port"
+	"app/pkg/errtrace"
 	pgxTransactor "app/pkg/transactor/pgx"
 
 	sq "github.com/Masterminds/squirrel"
@@ -15,6 +18,18 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const (
+	usersImportTable = "users_import"
+
+	usersExportCursor    = "users_export"
+	usersExportBatchSize = 500
+
+	createUsersImportTableSQL = `CREATE TEMP TABLE ` + usersImportTable + ` (
+		id UUID NOT NULL,
+		username VARCHAR(50) NOT NULL
+	) ON COMMIT DROP`
+)
+
 type UserRepository struct {
 	dbGetter pgxTransactor.DBGetter
 }
@@ -31,7 +46,7 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 		Suffix("RETURNING created_at").
 		ToSql()
 	if err != nil {
-		return fmt.Errorf("make query: %w", err)
+		return errtrace.Errorf("make query: %w", err)
 	}
 
 	err = r.dbGetter(ctx).QueryRow(ctx, sql, args...).Scan(&user.CreatedAt)
@@ -42,12 +57,87 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
 			}
 		}
 
-		return fmt.Errorf("execute query: %w", err)
+		return errtrace.Errorf("execute query: %w", err)
 	}
 
 	return nil
 }
 
+// CreateMany loads users into a transaction-scoped staging table with COPY and moves
+// them into users, skipping rows that conflict with existing usernames.
+func (r *UserRepository) CreateMany(ctx context.Context, users []*entity.User) ([]*entity.User, error) {
+	db := r.dbGetter(ctx)
+
+	_, err := db.Exec(ctx, createUsersImportTableSQL)
+	if err != nil {
+		return nil, errtrace.Errorf("create staging table: %w", err)
+	}
+
+	_, err = db.CopyFrom(
+		ctx,
+		pgx.Identifier{usersImportTable},
+		[]string{"id", "username"},
+		pgx.CopyFromSlice(len(users), func(i int) ([]any, error) {
+			return []any{users[i].ID, users[i].Username}, nil
+		}),
+	)
+	if err != nil {
+		return nil, errtrace.Errorf("copy users: %w", err)
+	}
+
+	sql, args, err := psql.
+		Insert("users").
+		Columns("id", "username").
+		Select(psql.Select("id", "username").From(usersImportTable)).
+		Suffix("ON CONFLICT DO NOTHING RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[uuid.UUID]*entity.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	for rows.Next() {
+		var (
+			id        uuid.UUID
+			createdAt time.Time
+		)
+
+		if err := rows.Scan(&id, &createdAt); err != nil {
+			return nil, errtrace.Errorf("scan user: %w", err)
+		}
+
+		if user, ok := byID[id]; ok {
+			user.CreatedAt = createdAt
+
+			delete(byID, id)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	existing := make([]*entity.User, 0, len(byID))
+
+	for _, user := range users {
+		if _, ok := byID[user.ID]; ok {
+			existing = append(existing, user)
+		}
+	}
+
+	return existing, nil
+}
+
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	sql, args, err := psql.
 		Select("id", "username", "created_at").
@@ -55,19 +145,152 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Use
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("make query: %w", err)
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	row := r.dbGetter(ctx).QueryRow(ctx, sql, args...)
+
+	user, err := r.scanUser(row)
+	if err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	sql, args, err := psql.
+		Select("id", "username", "created_at").
+		From("users").
+		Where(sq.Expr("lower(username) = lower(?)", username)).
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
 	}
 
 	row := r.dbGetter(ctx).QueryRow(ctx, sql, args...)
 
 	user, err := r.scanUser(row)
 	if err != nil {
-		return nil, fmt.Errorf("execute query: %w", err)
+		return nil, errtrace.Errorf("execute query: %w", err)
 	}
 
 	return user, nil
 }
 
+func (r *UserRepository) List(ctx context.Context, input dto.ListUsers) ([]*entity.User, error) {
+	sql, args, err := r.selectUsers(input.Filter).
+		Limit(input.Limit).
+		Offset(input.Offset).
+		ToSql()
+	if err != nil {
+		return nil, errtrace.Errorf("make query: %w", err)
+	}
+
+	rows, err := r.dbGetter(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+
+	for rows.Next() {
+		user, err := r.scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errtrace.Errorf("execute query: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) Stream(ctx context.Context, filter dto.UserFilter, fn func(*entity.User) error) error {
+	sql, args, err := r.selectUsers(filter).ToSql()
+	if err != nil {
+		return errtrace.Errorf("make query: %w", err)
+	}
+
+	db := r.dbGetter(ctx)
+
+	if _, err := db.Exec(ctx, "DECLARE "+usersExportCursor+" NO SCROLL CURSOR FOR "+sql, args...); err != nil {
+		return errtrace.Errorf("declare cursor: %w", err)
+	}
+
+	for {
+		fetched, err := r.fetchUsers(ctx, db, fn)
+		if err != nil {
+			return err
+		}
+
+		if fetched < usersExportBatchSize {
+			break
+		}
+	}
+
+	if _, err := db.Exec(ctx, "CLOSE "+usersExportCursor); err != nil {
+		return errtrace.Errorf("close cursor: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) fetchUsers(ctx context.Context, db pgxTransactor.DB, fn func(*entity.User) error) (int, error) {
+	rows, err := db.Query(ctx, fmt.Sprintf("FETCH FORWARD %d FROM %s", usersExportBatchSize, usersExportCursor))
+	if err != nil {
+		return 0, errtrace.Errorf("fetch cursor: %w", err)
+	}
+	defer rows.Close()
+
+	fetched := 0
+
+	for rows.Next() {
+		user, err := r.scanUser(rows)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := fn(user); err != nil {
+			return 0, err
+		}
+
+		fetched++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, errtrace.Errorf("fetch cursor: %w", err)
+	}
+
+	return fetched, nil
+}
+
+func (r *UserRepository) selectUsers(filter dto.UserFilter) sq.SelectBuilder {
+	query := psql.
+		Select("id", "username", "created_at").
+		From("users").
+		OrderBy("id")
+
+	if filter.Username != "" {
+		query = query.Where(sq.ILike{"username": "%" + likeEscaper.Replace(filter.Username) + "%"})
+	}
+
+	if filter.CreatedAfter != nil {
+		query = query.Where(sq.GtOrEq{"created_at": *filter.CreatedAfter})
+	}
+
+	if filter.CreatedBefore != nil {
+		query = query.Where(sq.Lt{"created_at": *filter.CreatedBefore})
+	}
+
+	return query
+}
+
 func (r *UserRepository) scanUser(row pgx.Row) (*entity.User, error) {
 	user := &entity.User{}
 
@@ -81,7 +304,7 @@ func (r *UserRepository) scanUser(row pgx.Row) (*entity.User, error) {
 			return nil, port.ErrUserNotFound
 		}
 
-		return nil, fmt.Errorf("scan user: %w", err)
+		return nil, errtrace.Errorf("scan user: %w", err)
 	}
 
 	return user, nil
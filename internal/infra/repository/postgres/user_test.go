@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"app/internal/core/dto"
 	"app/internal/core/entity"
 	"app/internal/core/port"
 	"app/pkg/transactor"
@@ -221,3 +222,217 @@ func TestUserRepository_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_CreateMany(t *testing.T) {
+	t.Parallel()
+
+	newUsers := func() []*entity.User {
+		return []*entity.User{
+			{ID: uuid.New(), Username: "alice"},
+			{ID: uuid.New(), Username: "bob"},
+		}
+	}
+	mockTime := time.Now()
+	genericErr := errors.New("something went wrong")
+
+	sql, _, err := psql.
+		Insert("users").
+		Columns("id", "username").
+		Select(psql.Select("id", "username").From(usersImportTable)).
+		Suffix("ON CONFLICT DO NOTHING RETURNING id, created_at").
+		ToSql()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		setupMock        func(mock pgxmock.PgxPoolIface, users []*entity.User)
+		expectedExisting func(users []*entity.User) []*entity.User
+		expectedErr      error
+	}{
+		{
+			name: "Success With Existing",
+			setupMock: func(mock pgxmock.PgxPoolIface, users []*entity.User) {
+				mock.ExpectExec(regexp.QuoteMeta(createUsersImportTableSQL)).
+					WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom(pgx.Identifier{usersImportTable}, []string{"id", "username"}).
+					WillReturnResult(2)
+				mock.ExpectQuery(regexp.QuoteMeta(sql)).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(users[0].ID, mockTime))
+			},
+			expectedExisting: func(users []*entity.User) []*entity.User {
+				return users[1:]
+			},
+		},
+		{
+			name: "Copy Error",
+			setupMock: func(mock pgxmock.PgxPoolIface, _ []*entity.User) {
+				mock.ExpectExec(regexp.QuoteMeta(createUsersImportTableSQL)).
+					WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom(pgx.Identifier{usersImportTable}, []string{"id", "username"}).
+					WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+		{
+			name: "Insert Error",
+			setupMock: func(mock pgxmock.PgxPoolIface, _ []*entity.User) {
+				mock.ExpectExec(regexp.QuoteMeta(createUsersImportTableSQL)).
+					WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom(pgx.Identifier{usersImportTable}, []string{"id", "username"}).
+					WillReturnResult(2)
+				mock.ExpectQuery(regexp.QuoteMeta(sql)).
+					WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewUserRepository(dbGetter)
+			users := newUsers()
+
+			tc.setupMock(mockPool, users)
+
+			existing, err := repo.CreateMany(context.Background(), users)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedExisting(users), existing)
+				assert.Equal(t, mockTime, users[0].CreatedAt)
+			}
+
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_List(t *testing.T) {
+	t.Parallel()
+
+	createdAfter := time.Now().Add(-time.Hour)
+	mockUser := &entity.User{
+		ID:        uuid.New(),
+		Username:  "john_doe",
+		CreatedAt: time.Now(),
+	}
+
+	input := dto.ListUsers{
+		Filter: dto.UserFilter{
+			Username:     "n_d%",
+			CreatedAfter: &createdAfter,
+		},
+		Limit:  10,
+		Offset: 20,
+	}
+
+	_, dbGetter, mockPool := newTestMock(t)
+	repo := NewUserRepository(dbGetter)
+
+	mockPool.ExpectQuery(regexp.QuoteMeta(
+		"SELECT id, username, created_at FROM users WHERE username ILIKE $1 AND created_at >= $2 "+
+			"ORDER BY id LIMIT 10 OFFSET 20",
+	)).
+		WithArgs(`%n\_d\%%`, createdAfter).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "username", "created_at"}).
+			AddRow(mockUser.ID, mockUser.Username, mockUser.CreatedAt))
+
+	users, err := repo.List(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.User{mockUser}, users)
+	assert.NoError(t, mockPool.ExpectationsWereMet())
+}
+
+func TestUserRepository_Stream(t *testing.T) {
+	t.Parallel()
+
+	genericErr := errors.New("something went wrong")
+	fetchSQL := fmt.Sprintf("FETCH FORWARD %d FROM %s", usersExportBatchSize, usersExportCursor)
+
+	newRows := func(n int) *pgxmock.Rows {
+		rows := pgxmock.NewRows([]string{"id", "username", "created_at"})
+		for i := range n {
+			rows.AddRow(uuid.New(), fmt.Sprintf("user%d", i), time.Now())
+		}
+
+		return rows
+	}
+
+	testCases := []struct {
+		name          string
+		setupMock     func(mock pgxmock.PgxPoolIface)
+		fnErr         error
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name: "Success In Batches",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec(regexp.QuoteMeta(
+					"DECLARE users_export NO SCROLL CURSOR FOR SELECT id, username, created_at FROM users " +
+						"WHERE username ILIKE $1 ORDER BY id",
+				)).
+					WithArgs("%john%").
+					WillReturnResult(pgxmock.NewResult("DECLARE CURSOR", 0))
+				mock.ExpectQuery(regexp.QuoteMeta(fetchSQL)).WillReturnRows(newRows(usersExportBatchSize))
+				mock.ExpectQuery(regexp.QuoteMeta(fetchSQL)).WillReturnRows(newRows(3))
+				mock.ExpectExec(regexp.QuoteMeta("CLOSE users_export")).
+					WillReturnResult(pgxmock.NewResult("CLOSE CURSOR", 0))
+			},
+			expectedCount: usersExportBatchSize + 3,
+		},
+		{
+			name: "Callback Error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("DECLARE users_export").
+					WithArgs("%john%").
+					WillReturnResult(pgxmock.NewResult("DECLARE CURSOR", 0))
+				mock.ExpectQuery(regexp.QuoteMeta(fetchSQL)).WillReturnRows(newRows(3))
+			},
+			fnErr:         genericErr,
+			expectedCount: 1,
+			expectedErr:   genericErr,
+		},
+		{
+			name: "Declare Error",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectExec("DECLARE users_export").
+					WithArgs("%john%").
+					WillReturnError(genericErr)
+			},
+			expectedErr: genericErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, dbGetter, mockPool := newTestMock(t)
+			repo := NewUserRepository(dbGetter)
+
+			tc.setupMock(mockPool)
+
+			count := 0
+			err := repo.Stream(context.Background(), dto.UserFilter{Username: "john"}, func(*entity.User) error {
+				count++
+
+				return tc.fnErr
+			})
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expectedCount, count)
+			assert.NoError(t, mockPool.ExpectationsWereMet())
+		})
+	}
+}
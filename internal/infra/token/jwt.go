@@ -0,0 +1,62 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/pkg/jwt"
+
+	jwtLib "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// JWTIssuer issues access tokens as signed JWTs, with the user id as the subject
+// and the scopes as a space-delimited scope claim.
+type JWTIssuer struct {
+	manager *jwt.Manager
+}
+
+func NewJWTIssuer(manager *jwt.Manager) *JWTIssuer {
+	return &JWTIssuer{manager: manager}
+}
+
+func (i *JWTIssuer) Issue(principal *entity.Principal, expiresAt time.Time) (string, error) {
+	now := time.Now()
+
+	return i.manager.Sign(jwt.Claims{
+		RegisteredClaims: jwtLib.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   principal.UserID.String(),
+			IssuedAt:  jwtLib.NewNumericDate(now),
+			NotBefore: jwtLib.NewNumericDate(now),
+			ExpiresAt: jwtLib.NewNumericDate(expiresAt),
+		},
+		Scope: strings.Join(principal.Scopes, " "),
+	})
+}
+
+func (i *JWTIssuer) Verify(token string) (*entity.Principal, error) {
+	claims, err := i.manager.Parse(token)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, port.ErrTokenExpired
+		}
+
+		return nil, port.ErrInvalidToken
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: subject: %w", port.ErrInvalidToken, err)
+	}
+
+	return &entity.Principal{
+		Kind:   entity.PrincipalKindUser,
+		UserID: userID,
+		Scopes: strings.Fields(claims.Scope),
+	}, nil
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth.go
+//
+// Generated by this command:
+//
+//	mockgen -source=auth.go -destination=../../mocks/access_token_issuer.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	entity "app/internal/core/entity"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAccessTokenIssuer is a mock of AccessTokenIssuer interface.
+type MockAccessTokenIssuer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessTokenIssuerMockRecorder
+	isgomock struct{}
+}
+
+// MockAccessTokenIssuerMockRecorder is the mock recorder for MockAccessTokenIssuer.
+type MockAccessTokenIssuerMockRecorder struct {
+	mock *MockAccessTokenIssuer
+}
+
+// NewMockAccessTokenIssuer creates a new mock instance.
+func NewMockAccessTokenIssuer(ctrl *gomock.Controller) *MockAccessTokenIssuer {
+	mock := &MockAccessTokenIssuer{ctrl: ctrl}
+	mock.recorder = &MockAccessTokenIssuerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessTokenIssuer) EXPECT() *MockAccessTokenIssuerMockRecorder {
+	return m.recorder
+}
+
+// Issue mocks base method.
+func (m *MockAccessTokenIssuer) Issue(principal *entity.Principal, expiresAt time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Issue", principal, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Issue indicates an expected call of Issue.
+func (mr *MockAccessTokenIssuerMockRecorder) Issue(principal, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockAccessTokenIssuer)(nil).Issue), principal, expiresAt)
+}
+
+// Verify mocks base method.
+func (m *MockAccessTokenIssuer) Verify(token string) (*entity.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token)
+	ret0, _ := ret[0].(*entity.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockAccessTokenIssuerMockRecorder) Verify(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockAccessTokenIssuer)(nil).Verify), token)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth.go
+//
+// Generated by this command:
+//
+//	mockgen -source=auth.go -destination=../../mocks/credentials_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "app/internal/core/entity"
+	types "app/internal/types"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCredentialsRepository is a mock of CredentialsRepository interface.
+type MockCredentialsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCredentialsRepositoryMockRecorder is the mock recorder for MockCredentialsRepository.
+type MockCredentialsRepositoryMockRecorder struct {
+	mock *MockCredentialsRepository
+}
+
+// NewMockCredentialsRepository creates a new mock instance.
+func NewMockCredentialsRepository(ctrl *gomock.Controller) *MockCredentialsRepository {
+	mock := &MockCredentialsRepository{ctrl: ctrl}
+	mock.recorder = &MockCredentialsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialsRepository) EXPECT() *MockCredentialsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetForUpdate mocks base method.
+func (m *MockCredentialsRepository) GetForUpdate(ctx context.Context, userID types.ID) (*entity.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUpdate", ctx, userID)
+	ret0, _ := ret[0].(*entity.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUpdate indicates an expected call of GetForUpdate.
+func (mr *MockCredentialsRepositoryMockRecorder) GetForUpdate(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUpdate", reflect.TypeOf((*MockCredentialsRepository)(nil).GetForUpdate), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockCredentialsRepository) Save(ctx context.Context, credentials *entity.Credentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, credentials)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCredentialsRepositoryMockRecorder) Save(ctx, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCredentialsRepository)(nil).Save), ctx, credentials)
+}
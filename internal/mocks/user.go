@@ -13,8 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
-	"app/internal/core/dto"
-	"app/internal/core/entity"
+	dto "app/internal/core/dto"
+	entity "app/internal/core/entity"
 	types "app/internal/types"
 
 	gomock "go.uber.org/mock/gomock"
@@ -59,6 +59,20 @@ func (mr *MockUserServiceMockRecorder) Create(ctx, input any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserService)(nil).Create), ctx, input)
 }
 
+// Export mocks base method.
+func (m *MockUserService) Export(ctx context.Context, filter dto.UserFilter, fn func(*entity.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserServiceMockRecorder) Export(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUserService)(nil).Export), ctx, filter, fn)
+}
+
 // GetByID mocks base method.
 func (m *MockUserService) GetByID(ctx context.Context, id types.ID) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -73,3 +87,33 @@ func (mr *MockUserServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserService)(nil).GetByID), ctx, id)
 }
+
+// Import mocks base method.
+func (m *MockUserService) Import(ctx context.Context, input dto.ImportUsers) (*dto.ImportUsersReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, input)
+	ret0, _ := ret[0].(*dto.ImportUsersReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserServiceMockRecorder) Import(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserService)(nil).Import), ctx, input)
+}
+
+// List mocks base method.
+func (m *MockUserService) List(ctx context.Context, input dto.ListUsers) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, input)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserServiceMockRecorder) List(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserService)(nil).List), ctx, input)
+}
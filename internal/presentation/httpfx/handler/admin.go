@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"runtime/debug"
+	"time"
+
+	"app/config"
+	"app/internal/core/port"
+	"app/pkg/health"
+	"app/pkg/logger"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/adaptor"
+	"github.com/gofiber/fiber/v3/middleware/pprof"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+)
+
+// ApplyAdminRoutes registers the operational endpoints on the admin listener.
+func ApplyAdminRoutes(app *fiber.App, cfg *config.Config, checks *health.Registry) {
+	app.Use(AdminAuth(cfg.Admin.Token))
+	app.Use(pprof.New())
+
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+	app.Get("/healthz", Healthz(checks))
+	app.Get("/buildinfo", BuildInfo)
+	app.Get("/config", EffectiveConfig(cfg))
+	app.Get("/loglevel", GetLogLevel)
+	app.Put("/loglevel", SetLogLevel)
+	app.Delete("/loglevel", ResetLogLevel)
+}
+
+// AdminAuth requires the static admin token as a bearer token. Without a token the
+// listener relies on mTLS alone, which is enforced by the TLS handshake.
+func AdminAuth(token string) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		if token == "" {
+			return ctx.Next()
+		}
+
+		if subtle.ConstantTimeCompare([]byte(bearerToken(ctx)), []byte(token)) != 1 {
+			ctx.Set(fiber.HeaderWWWAuthenticate, bearerScheme)
+
+			return port.ErrUnauthenticated
+		}
+
+		return ctx.Next()
+	}
+}
+
+type buildInfoResponse struct {
+	GoVersion string `json:"go_version"`
+	Path      string `json:"path"`
+	Version   string `json:"version"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified"`
+}
+
+// BuildInfo returns the Go version, module version and VCS revision the binary was built from.
+func BuildInfo(ctx fiber.Ctx) error {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return fiber.NewError(fiber.StatusNotFound, "build info unavailable")
+	}
+
+	resp := buildInfoResponse{
+		GoVersion: info.GoVersion,
+		Path:      info.Main.Path,
+		Version:   info.Main.Version,
+	}
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			resp.Revision = setting.Value
+		case "vcs.time":
+			resp.Time = setting.Value
+		case "vcs.modified":
+			resp.Modified = setting.Value == "true"
+		}
+	}
+
+	return ctx.JSON(resp)
+}
+
+// EffectiveConfig returns the configuration the application runs with, secrets redacted.
+func EffectiveConfig(cfg *config.Config) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		return ctx.JSON(cfg.Redacted())
+	}
+}
+
+type logLevelRequest struct {
+	Level string `json:"level" validate:"required"`
+	// Component is the component to set the level of, every logger when empty.
+	Component string `json:"component"`
+	// TTL is how long the level lasts, LOG_LEVEL_OVERRIDE_TTL when empty.
+	TTL string `json:"ttl"`
+}
+
+type logLevelResponse struct {
+	Level     string            `json:"level"`
+	Overrides []logger.Override `json:"overrides"`
+}
+
+func newLogLevelResponse() logLevelResponse {
+	return logLevelResponse{
+		Level:     logger.Level("").String(),
+		Overrides: logger.Overrides(),
+	}
+}
+
+func GetLogLevel(ctx fiber.Ctx) error {
+	return ctx.JSON(newLogLevelResponse())
+}
+
+// SetLogLevel overrides the level of a component or of every logger, until the TTL elapses.
+func SetLogLevel(ctx fiber.Ctx) error {
+	req := new(logLevelRequest)
+	if err := ctx.Bind().JSON(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	level, err := zerolog.ParseLevel(req.Level)
+	if err != nil {
+		return newValidationError("level", "unknown log level")
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+			return newValidationError("ttl", "ttl must be a positive duration")
+		}
+	}
+
+	logger.SetLevel(req.Component, level, ttl)
+
+	return ctx.JSON(newLogLevelResponse())
+}
+
+// ResetLogLevel reverts the level of the component given by the query, or the global level, to the configured one.
+func ResetLogLevel(ctx fiber.Ctx) error {
+	logger.ResetLevel(ctx.Query("component"))
+
+	return ctx.JSON(newLogLevelResponse())
+}
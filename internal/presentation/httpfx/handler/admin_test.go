@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/config"
+	"app/pkg/health"
+	"app/pkg/httpserver"
+	"app/pkg/logger"
+	"app/pkg/postgres"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminRoutes(t *testing.T) {
+	require.NoError(t, logger.Reload(logger.Config{LogLevel: zerolog.InfoLevel, LevelOverrideTTL: time.Minute}))
+	t.Cleanup(func() {
+		logger.ResetLevel("")
+		logger.ResetLevel("sql")
+	})
+
+	cfg := &config.Config{
+		Admin:    httpserver.AdminConfig{Token: "admin-token"},
+		Postgres: postgres.Config{Host: "postgres", Password: "password"},
+	}
+
+	checks, err := health.NewRegistry(health.Config{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		method         string
+		target         string
+		token          string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Missing Token",
+			method:         "GET",
+			target:         "/loglevel",
+			expectedStatus: fiber.StatusUnauthorized,
+			expectedBody:   `"error_code":"auth.unauthenticated"`,
+		},
+		{
+			name:           "Wrong Token",
+			method:         "GET",
+			target:         "/loglevel",
+			token:          "guess",
+			expectedStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:           "Get Log Level",
+			method:         "GET",
+			target:         "/loglevel",
+			token:          "admin-token",
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   `{"level":"info","overrides":[]}`,
+		},
+		{
+			name:           "Set Component Log Level",
+			method:         "PUT",
+			target:         "/loglevel",
+			token:          "admin-token",
+			body:           `{"level":"trace","component":"sql","ttl":"5m"}`,
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   `{"level":"info","overrides":[{"component":"sql","level":"trace",`,
+		},
+		{
+			name:           "Set Log Level",
+			method:         "PUT",
+			target:         "/loglevel",
+			token:          "admin-token",
+			body:           `{"level":"debug"}`,
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   `{"level":"debug","overrides":[{"level":"debug",`,
+		},
+		{
+			name:           "Reset Component Log Level",
+			method:         "DELETE",
+			target:         "/loglevel?component=sql",
+			token:          "admin-token",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Invalid TTL",
+			method:         "PUT",
+			target:         "/loglevel",
+			token:          "admin-token",
+			body:           `{"level":"debug","ttl":"soon"}`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody:   `"field":"ttl"`,
+		},
+		{
+			name:           "Unknown Log Level",
+			method:         "PUT",
+			target:         "/loglevel",
+			token:          "admin-token",
+			body:           `{"level":"verbose"}`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody:   `"field":"level"`,
+		},
+		{
+			name:           "Redacted Config",
+			method:         "GET",
+			target:         "/config",
+			token:          "admin-token",
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   `"POSTGRES_PASSWORD":"[REDACTED]"`,
+		},
+		{
+			name:           "Health",
+			method:         "GET",
+			target:         "/healthz",
+			token:          "admin-token",
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   `{"status":"ok"}`,
+		},
+		{
+			name:           "Pprof",
+			method:         "GET",
+			target:         "/debug/pprof/cmdline",
+			token:          "admin-token",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Metrics",
+			method:         "GET",
+			target:         "/metrics",
+			token:          "admin-token",
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   "go_goroutines",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app, err := httpserver.NewAdmin(cfg.Admin, ErrorHandler, StructValidator())
+			require.NoError(t, err)
+
+			ApplyAdminRoutes(app, cfg, checks)
+
+			req := httptest.NewRequest(tc.method, tc.target, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
+		})
+	}
+
+	assert.Equal(t, zerolog.DebugLevel, logger.Level(""))
+	assert.Equal(t, zerolog.DebugLevel, logger.Level("sql"))
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/types"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type createAPIKeyRequest struct {
+	Name      string              `json:"name" validate:"required,max=64"`
+	Scopes    []entity.Permission `json:"scopes" swaggertype:"array,string" example:"users:read"`
+	ExpiresAt *time.Time          `json:"expires_at"`
+}
+
+type apiKeyResponse struct {
+	ID        types.ID            `json:"id"`
+	Name      string              `json:"name"`
+	Prefix    string              `json:"prefix"`
+	Scopes    []entity.Permission `json:"scopes" swaggertype:"array,string" example:"users:read"`
+	ExpiresAt *time.Time          `json:"expires_at"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+type createdAPIKeyResponse struct {
+	apiKeyResponse
+
+	// Key is only returned once, at creation.
+	Key string `json:"key"`
+}
+
+// CreateAPIKey
+//
+//	@Summary		Create an API key
+//	@Description	Create an API key acting on behalf of the current user for machine clients.
+//	@Description	The scopes must be permissions the user has. The key is only returned in this response.
+//	@Tags			api-keys
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			payload	body		createAPIKeyRequest	true	"API key"
+//	@Success		201		{object}	createdAPIKeyResponse
+//	@Failure		400		{object}	ClientError
+//	@Failure		401		{object}	ClientError
+//	@Failure		403		{object}	ClientError
+//	@Failure		422		{object}	ValidationError
+//	@Router			/api-keys [post]
+func (h *Handler) CreateAPIKey(ctx fiber.Ctx) error {
+	req := new(createAPIKeyRequest)
+	if err := ctx.Bind().JSON(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	created, err := h.app.APIKeyService.Create(ctx.Context(), dto.CreateAPIKey{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("create api key: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(createdAPIKeyResponse{
+		apiKeyResponse: apiKeyResponse{
+			ID:        created.APIKey.ID,
+			Name:      created.APIKey.Name,
+			Prefix:    created.APIKey.Prefix,
+			Scopes:    created.APIKey.Scopes,
+			ExpiresAt: created.APIKey.ExpiresAt,
+			CreatedAt: created.APIKey.CreatedAt,
+		},
+		Key: created.Key,
+	})
+}
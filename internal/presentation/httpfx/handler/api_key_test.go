@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"app/internal/core"
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/mocks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyHandler_CreateAPIKey(t *testing.T) {
+	input := dto.CreateAPIKey{Name: "ci", Scopes: []entity.Permission{entity.PermissionUsersRead}}
+	created := &dto.CreatedAPIKey{
+		APIKey: &entity.APIKey{
+			ID:     uuid.Must(uuid.NewV7()),
+			Name:   "ci",
+			Prefix: "a1b2c3",
+			Scopes: input.Scopes,
+		},
+		Key: "gohex_a1b2c3.secret",
+	}
+
+	testCases := []struct {
+		name           string
+		body           []byte
+		setupMock      func(m *mocks.MockAPIKeyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success",
+			body: []byte(`{"name":"ci","scopes":["users:read"]}`),
+			setupMock: func(m *mocks.MockAPIKeyService) {
+				m.EXPECT().Create(gomock.Any(), input).Return(created, nil).Times(1)
+			},
+			expectedStatus: fiber.StatusCreated,
+			expectedBody:   `"key":"gohex_a1b2c3.secret"`,
+		},
+		{
+			name: "Scope Not Granted",
+			body: []byte(`{"name":"ci","scopes":["users:read"]}`),
+			setupMock: func(m *mocks.MockAPIKeyService) {
+				m.EXPECT().Create(gomock.Any(), input).Return(nil, port.ErrForbidden).Times(1)
+			},
+			expectedStatus: fiber.StatusForbidden,
+			expectedBody:   `"message":"access denied"`,
+		},
+		{
+			name:           "Invalid Name",
+			body:           []byte(`{"name":"","scopes":["users:read"]}`),
+			setupMock:      func(m *mocks.MockAPIKeyService) {},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody:   `{"field":"name","code":"validation.required","message":"name is required"}`,
+		},
+		{
+			name: "Invalid Expiry",
+			body: []byte(`{"name":"ci","scopes":["users:read"],"expires_at":"2020-01-01T00:00:00Z"}`),
+			setupMock: func(m *mocks.MockAPIKeyService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, entity.ErrAPIKeyExpiryInPast).Times(1)
+			},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody:   `"field":"expires_at"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAPIKeyService := mocks.NewMockAPIKeyService(ctrl)
+			tc.setupMock(mockAPIKeyService)
+
+			handler := NewHandler(&core.Application{APIKeyService: mockAPIKeyService})
+
+			router := fiber.New(fiber.Config{
+				ErrorHandler:    ErrorHandler,
+				StructValidator: StructValidator(),
+			})
+			router.Post("/api-keys", handler.CreateAPIKey)
+
+			req := httptest.NewRequest("POST", "/api-keys", bytes.NewBuffer(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"app/internal/core/dto"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type loginRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type authTokensResponse struct {
+	TokenType             string    `json:"token_type" example:"Bearer"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+func newAuthTokensResponse(tokens *dto.AuthTokens) authTokensResponse {
+	return authTokensResponse{
+		TokenType:             bearerScheme,
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
+
+// Login
+//
+//	@Summary		Log in
+//	@Description	Exchange a username and password for an access and refresh token pair.
+//	@Description	Repeated failures temporarily lock the account.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		loginRequest	true	"Credentials"
+//	@Success		200		{object}	authTokensResponse
+//	@Failure		400		{object}	ClientError
+//	@Failure		401		{object}	ClientError
+//	@Router			/auth/login [post]
+func (h *Handler) Login(ctx fiber.Ctx) error {
+	req := new(loginRequest)
+	if err := ctx.Bind().JSON(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	tokens, err := h.app.AuthService.Login(ctx.Context(), dto.Login{
+		Username: req.Username,
+		Password: req.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+
+	return ctx.JSON(newAuthTokensResponse(tokens))
+}
+
+// RefreshToken
+//
+//	@Summary		Refresh tokens
+//	@Description	Exchange a refresh token for a new token pair. Refresh tokens are single-use:
+//	@Description	presenting an already exchanged token revokes every token issued since the login.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		refreshTokenRequest	true	"Refresh token"
+//	@Success		200		{object}	authTokensResponse
+//	@Failure		400		{object}	ClientError
+//	@Failure		401		{object}	ClientError
+//	@Router			/auth/refresh [post]
+func (h *Handler) RefreshToken(ctx fiber.Ctx) error {
+	req := new(refreshTokenRequest)
+	if err := ctx.Bind().JSON(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	tokens, err := h.app.AuthService.Refresh(ctx.Context(), req.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("refresh token: %w", err)
+	}
+
+	return ctx.JSON(newAuthTokensResponse(tokens))
+}
+
+// Logout
+//
+//	@Summary		Log out
+//	@Description	Revoke the refresh token and every token issued since the same login.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		refreshTokenRequest	true	"Refresh token"
+//	@Success		200		{object}	MessageResponse
+//	@Failure		400		{object}	ClientError
+//	@Failure		401		{object}	ClientError
+//	@Router			/auth/logout [post]
+func (h *Handler) Logout(ctx fiber.Ctx) error {
+	req := new(refreshTokenRequest)
+	if err := ctx.Bind().JSON(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	if err := h.app.AuthService.Logout(ctx.Context(), req.RefreshToken); err != nil {
+		return fmt.Errorf("logout: %w", err)
+	}
+
+	return ctx.JSON(MessageResponse{Message: "ok"})
+}
+
+// Me
+//
+//	@Summary		Current user
+//	@Description	Retrieve the authenticated user.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	userResponse
+//	@Failure		401	{object}	ClientError
+//	@Router			/auth/me [get]
+func (h *Handler) Me(ctx fiber.Ctx) error {
+	principal, err := principalFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.app.UserService.GetByID(ctx.Context(), principal.UserID)
+	if err != nil {
+		return fmt.Errorf("get current user: %w", err)
+	}
+
+	return ctx.JSON(newUserResponse(user))
+}
@@ -6,14 +6,17 @@ import (
 	"net/http"
 
 	domainErrors "app/internal/core/error"
+	"app/pkg/errtrace"
 
 	"github.com/gofiber/fiber/v3"
-	"github.com/gofiber/schema"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ClientError struct {
 	StatusCode int            `json:"-"`
 	Code       int            `json:"code"`
+	ErrorCode  string         `json:"error_code,omitempty" example:"user.not_found"`
 	Message    string         `json:"message"`
 	Data       map[string]any `json:"data,omitempty"`
 }
@@ -22,58 +25,6 @@ func (c *ClientError) Error() string {
 	return fmt.Sprintf("client error - code: %d; message: %s", c.Code, c.Message)
 }
 
-type ValidationError struct {
-	ClientError
-
-	Field string `json:"field"`
-}
-
-func (ve *ValidationError) Error() string {
-	return fmt.Sprintf("validation error - field: %s; message: %s", ve.Field, ve.Message)
-}
-
-func newValidationError(field, message string, code ...int) *ValidationError {
-	ve := &ValidationError{
-		ClientError: ClientError{
-			Message: message,
-		},
-		Field: field,
-	}
-	if len(code) > 0 {
-		ve.Code = code[0]
-	} else {
-		ve.Code = http.StatusUnprocessableEntity
-	}
-
-	return ve
-}
-
-func newBindError(err error) error {
-	unwrappedErr := errors.Unwrap(err)
-
-	var multiErr schema.MultiError
-	if errors.As(unwrappedErr, &multiErr) {
-		var (
-			conversionErr schema.ConversionError
-			unknownKeyErr schema.UnknownKeyError
-			emptyFieldErr schema.EmptyFieldError
-		)
-
-		for _, singleErr := range multiErr {
-			switch {
-			case errors.As(singleErr, &conversionErr):
-				return newValidationError(conversionErr.Key, conversionErr.Err.Error())
-			case errors.As(singleErr, &unknownKeyErr):
-				return newBadRequest(unknownKeyErr.Error())
-			case errors.As(singleErr, &emptyFieldErr):
-				return newValidationError(emptyFieldErr.Key, emptyFieldErr.Error())
-			}
-		}
-	}
-
-	return newBadRequest(err.Error())
-}
-
 func newBadRequest(message string, code ...int) *ClientError {
 	ce := &ClientError{
 		Message: message,
@@ -93,59 +44,141 @@ func newBadRequest(message string, code ...int) *ClientError {
 	return ce
 }
 
+// newDomainClientError maps a domain error to its client representation.
+// Registered errors take the HTTP status of their code definition.
+func newDomainClientError(domainErr *domainErrors.DomainError) *ClientError {
+	errResponse := &ClientError{
+		StatusCode: http.StatusBadRequest,
+		ErrorCode:  domainErr.Code(),
+		Message:    domainErr.Message(),
+	}
+
+	def, registered := domainErrors.Lookup(domainErr.Code())
+
+	switch {
+	case registered:
+		errResponse.Code = def.HTTPStatus
+		if def.HTTPStatus >= http.StatusBadRequest && def.HTTPStatus < http.StatusInternalServerError {
+			errResponse.StatusCode = def.HTTPStatus
+		}
+	case domainErr.Field() != "":
+		errResponse.StatusCode = http.StatusUnprocessableEntity
+		errResponse.Code = http.StatusUnprocessableEntity
+	default:
+		errResponse.Code = http.StatusBadRequest
+	}
+
+	if len(domainErr.Args()) > 0 {
+		errResponse.Data = make(map[string]any, len(domainErr.Args()))
+		for _, arg := range domainErr.Args() {
+			errResponse.Data[arg.Key] = arg.Value
+		}
+	}
+
+	return errResponse
+}
+
+const (
+	internalErrorLocalsKey = "internal_error"
+	errorCodeLocalsKey     = "error_code"
+)
+
+// ErrorHandler answers the request with the client representation of the error.
+// Unhandled errors are answered with a generic internal problem and kept for the request logger.
 func ErrorHandler(ctx fiber.Ctx, err error) error {
+	return handleError(ctx, err, false)
+}
+
+// DebugErrorHandler is the ErrorHandler that also includes the cause chain and the stack trace
+// of unhandled errors in the response. It must not be used in production.
+func DebugErrorHandler(ctx fiber.Ctx, err error) error {
+	return handleError(ctx, err, true)
+}
+
+// ErrorCode returns the error code the request was answered with, if any.
+func ErrorCode(ctx fiber.Ctx) string {
+	code, _ := ctx.Locals(errorCodeLocalsKey).(string)
+
+	return code
+}
+
+// InternalError returns the unhandled error the request was answered with, if any.
+func InternalError(ctx fiber.Ctx) error {
+	err, _ := ctx.Locals(internalErrorLocalsKey).(error)
+
+	return err
+}
+
+func handleError(ctx fiber.Ctx, err error, debug bool) error {
 	var (
 		clientErr     *ClientError
 		validationErr *ValidationError
 		fiberErr      *fiber.Error
+		fieldErrs     domainErrors.FieldErrors
 		domainErr     *domainErrors.DomainError
 	)
 
 	switch {
 	case errors.As(err, &clientErr):
-		return ctx.Status(clientErr.StatusCode).JSON(clientErr)
+		return writeClientError(ctx, clientErr, nil)
 	case errors.As(err, &validationErr):
-		return ctx.Status(http.StatusUnprocessableEntity).JSON(validationErr)
+		return writeClientError(ctx, &validationErr.ClientError, validationErr.Errors)
 	case errors.As(err, &fiberErr):
-		return ctx.Status(fiberErr.Code).JSON(&ClientError{
-			Code:    fiberErr.Code,
-			Message: fiberErr.Message,
-		})
+		return writeClientError(ctx, &ClientError{
+			StatusCode: fiberErr.Code,
+			Code:       fiberErr.Code,
+			Message:    fiberErr.Message,
+		}, nil)
+	case errors.As(err, &fieldErrs):
+		return writeClientError(ctx, newDomainClientError(fieldErrs[0]), newDomainFieldErrors(fieldErrs...))
 	case errors.As(err, &domainErr):
-		statusCode := http.StatusBadRequest
-
-		errResponse := &ClientError{
-			Message: domainErr.Message(),
+		if domainErr.Field() == "" {
+			return writeClientError(ctx, newDomainClientError(domainErr), nil)
 		}
 
-		if domainErr.Code() == 0 {
-			errResponse.Code = http.StatusBadRequest
-		} else {
-			errResponse.Code = domainErr.Code()
-			if domainErr.Code() >= http.StatusBadRequest && domainErr.Code() < http.StatusInternalServerError {
-				statusCode = domainErr.Code()
+		return writeClientError(ctx, newDomainClientError(domainErr), newDomainFieldErrors(domainErr))
+	default:
+		// logged with its cause chain and stack trace by the router logger
+		ctx.Locals(internalErrorLocalsKey, err)
+
+		span := trace.SpanFromContext(ctx.Context())
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		problem := newInternalProblem(ctx)
+		if debug {
+			problem.Extensions = map[string]any{"causes": errtrace.Chain(err)}
+			if trace := errtrace.StackTrace(err); trace != nil {
+				problem.Extensions["stack"] = trace
 			}
 		}
 
-		if len(domainErr.Args()) > 0 {
-			errResponse.Data = make(map[string]any, len(domainErr.Args()))
-			for _, arg := range domainErr.Args() {
-				errResponse.Data[arg.Key] = arg.Value
-			}
-		}
+		return writeProblem(ctx, problem)
+	}
+}
 
-		return ctx.Status(statusCode).JSON(errResponse)
-	default:
-		// h.observer.Logger.
-		//	Error().
-		//	Ctx(ctx.Context()).
-		//	Err(err).
-		//	Msg("unhandled error")
-		// LOGGED BY ROUTER LOGGER
-		ctx.Res().Status(http.StatusInternalServerError)
-
-		return nil
+// writeClientError renders the error as problem details when the client accepts them,
+// otherwise as a ClientError, or a ValidationError when the error is caused by fields.
+func writeClientError(ctx fiber.Ctx, clientErr *ClientError, fieldErrs []FieldError) error {
+	clientErr, fieldErrs = localizeError(ctx, clientErr, fieldErrs)
+
+	if clientErr.ErrorCode != "" {
+		ctx.Locals(errorCodeLocalsKey, clientErr.ErrorCode)
+	}
+
+	if acceptsProblem(ctx) {
+		return writeProblem(ctx, newProblem(ctx, clientErr, fieldErrs))
 	}
+
+	if len(fieldErrs) > 0 {
+		return ctx.Status(clientErr.StatusCode).JSON(&ValidationError{
+			ClientError: *clientErr,
+			Field:       fieldErrs[0].Field,
+			Errors:      fieldErrs,
+		})
+	}
+
+	return ctx.Status(clientErr.StatusCode).JSON(clientErr)
 }
 
 type MessageResponse struct {
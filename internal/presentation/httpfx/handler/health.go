@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+
+	"app/pkg/health"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Livez
+//
+//	@Summary		Liveness probe
+//	@Description	Fails when the process must be restarted, dependencies are not checked. Check errors are omitted.
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	health.Report
+//	@Failure		503	{object}	health.Report
+//	@Router			/livez [get]
+func Livez(registry *health.Registry) fiber.Handler {
+	return probe(registry.Live, false)
+}
+
+// Readyz
+//
+//	@Summary		Readiness probe
+//	@Description	Fails when a dependency is unavailable or the server is shutting down. Check errors are omitted.
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	health.Report
+//	@Failure		503	{object}	health.Report
+//	@Router			/readyz [get]
+func Readyz(registry *health.Registry) fiber.Handler {
+	return probe(registry.Ready, false)
+}
+
+// Healthz reports the errors of the checks, which may reveal the addresses of the dependencies,
+// so it is served on the admin listener only.
+//
+//	@Summary		Health details
+//	@Description	Result of every check, regardless of shutdown.
+//	@Tags			health
+//	@Produce		json
+//	@Success		200	{object}	health.Report
+//	@Failure		503	{object}	health.Report
+//	@Router			/healthz [get]
+func Healthz(registry *health.Registry) fiber.Handler {
+	return probe(registry.Health, true)
+}
+
+func probe(run func(context.Context) *health.Report, detailed bool) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		report := run(ctx.Context())
+		if !detailed {
+			report = withoutErrors(report)
+		}
+
+		ctx.Set(fiber.HeaderCacheControl, "no-store")
+
+		if !report.OK() {
+			return ctx.Status(fiber.StatusServiceUnavailable).JSON(report)
+		}
+
+		return ctx.JSON(report)
+	}
+}
+
+// withoutErrors copies the report without the errors of the checks.
+func withoutErrors(report *health.Report) *health.Report {
+	public := &health.Report{Status: report.Status}
+
+	if len(report.Checks) > 0 {
+		public.Checks = make(map[string]health.Result, len(report.Checks))
+
+		for name, result := range report.Checks {
+			result.Error = ""
+			public.Checks[name] = result
+		}
+	}
+
+	return public
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"app/pkg/health"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthProbes(t *testing.T) {
+	var postgresErr error
+
+	registry, err := health.NewRegistry(health.Config{}, health.Check{
+		Name: "postgres",
+		Func: func(context.Context) error { return postgresErr },
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		target         string
+		postgresErr    error
+		drain          bool
+		expectedStatus int
+		expectedReport health.Status
+		expectedChecks []string
+		expectedError  string
+	}{
+		{
+			name:           "Live",
+			target:         "/livez",
+			postgresErr:    errors.New("connection refused"),
+			expectedStatus: fiber.StatusOK,
+			expectedReport: health.StatusOK,
+			expectedChecks: []string{},
+		},
+		{
+			name:           "Ready",
+			target:         "/readyz",
+			expectedStatus: fiber.StatusOK,
+			expectedReport: health.StatusOK,
+			expectedChecks: []string{"postgres"},
+		},
+		{
+			name:           "Not Ready",
+			target:         "/readyz",
+			postgresErr:    errors.New("connection refused"),
+			expectedStatus: fiber.StatusServiceUnavailable,
+			expectedReport: health.StatusFailing,
+			expectedChecks: []string{"postgres"},
+		},
+		{
+			name:           "Health Failing",
+			target:         "/healthz",
+			postgresErr:    errors.New("connection refused"),
+			expectedStatus: fiber.StatusServiceUnavailable,
+			expectedReport: health.StatusFailing,
+			expectedChecks: []string{"postgres"},
+			expectedError:  "connection refused",
+		},
+		{
+			name:           "Draining",
+			target:         "/readyz",
+			drain:          true,
+			expectedStatus: fiber.StatusServiceUnavailable,
+			expectedReport: health.StatusDraining,
+			expectedChecks: []string{},
+		},
+		{
+			name:           "Health While Draining",
+			target:         "/healthz",
+			expectedStatus: fiber.StatusOK,
+			expectedReport: health.StatusOK,
+			expectedChecks: []string{"postgres"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			postgresErr = tc.postgresErr
+
+			if tc.drain {
+				registry.Drain()
+			}
+
+			router := fiber.New()
+			router.Get("/livez", Livez(registry))
+			router.Get("/readyz", Readyz(registry))
+			router.Get("/healthz", Healthz(registry))
+
+			resp, err := router.Test(httptest.NewRequest("GET", tc.target, nil))
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			var report health.Report
+			require.NoError(t, json.Unmarshal(bodyBytes, &report))
+			assert.Equal(t, tc.expectedReport, report.Status)
+
+			checks := []string{}
+			for name := range report.Checks {
+				checks = append(checks, name)
+			}
+
+			assert.Equal(t, tc.expectedChecks, checks)
+
+			for _, result := range report.Checks {
+				assert.Equal(t, tc.expectedError, result.Error)
+			}
+		})
+	}
+}
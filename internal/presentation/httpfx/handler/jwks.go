@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"app/pkg/jwt"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// JWKS
+//
+//	@Summary		JSON Web Key Set
+//	@Description	Public keys verifying the access tokens, selected by the kid token header.
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	jwt.JWKS
+//	@Router			/.well-known/jwks.json [get]
+func JWKS(keys *jwt.Manager) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+		return ctx.JSON(keys.JWKS())
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"maps"
+	"slices"
+
+	"app/pkg/i18n"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const localizerLocalsKey = "localizer"
+
+// Localize negotiates the language of the error messages from the Accept-Language header.
+func Localize(translations *i18n.Bundle) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		ctx.Locals(localizerLocalsKey, translations.Localizer(ctx.Get(fiber.HeaderAcceptLanguage)))
+
+		return ctx.Next()
+	}
+}
+
+// localizeError returns the error with its messages translated into the negotiated language.
+// Error codes are the translation keys and the error args fill the placeholders, messages
+// without a translation keep the English text of the code.
+func localizeError(ctx fiber.Ctx, clientErr *ClientError, fieldErrs []FieldError) (*ClientError, []FieldError) {
+	localizer, ok := ctx.Locals(localizerLocalsKey).(*i18n.Localizer)
+	if !ok {
+		return clientErr, fieldErrs
+	}
+
+	ctx.Vary(fiber.HeaderAcceptLanguage)
+
+	localizedErr := *clientErr
+	localizedFieldErrs := slices.Clone(fieldErrs)
+	translated := false
+
+	if message, ok := localizer.Translate(clientErr.ErrorCode, clientErr.Data); ok {
+		localizedErr.Message, translated = message, true
+	}
+
+	for i, fieldErr := range localizedFieldErrs {
+		key := fieldErr.messageKey
+		if key == "" {
+			key = fieldErr.Code
+		}
+
+		args := maps.Clone(fieldErr.Data)
+		if args == nil {
+			args = make(map[string]any, 1)
+		}
+
+		args["field"] = fieldErr.Field
+
+		if message, ok := localizer.Translate(key, args); ok {
+			localizedFieldErrs[i].Message, translated = message, true
+		}
+	}
+
+	if len(localizedFieldErrs) > 0 {
+		localizedErr.Message = localizedFieldErrs[0].Message
+	}
+
+	if translated {
+		ctx.Set(fiber.HeaderContentLanguage, localizer.Language().String())
+	}
+
+	return &localizedErr, localizedFieldErrs
+}
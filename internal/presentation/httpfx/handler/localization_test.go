@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"app/internal/core/port"
+	"app/locales"
+	"app/pkg/i18n"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalize(t *testing.T) {
+	translations, err := i18n.NewBundle(i18n.Config{DefaultLocale: "en"})
+	require.NoError(t, err)
+	require.NoError(t, translations.LoadFS(locales.FS))
+
+	testCases := []struct {
+		name                    string
+		method                  string
+		body                    string
+		acceptLanguage          string
+		expectedStatus          int
+		expectedContentLanguage string
+		expectedBody            string
+	}{
+		{
+			name:                    "Domain Error",
+			method:                  "GET",
+			acceptLanguage:          "uk-UA,uk;q=0.9,en;q=0.8",
+			expectedStatus:          fiber.StatusForbidden,
+			expectedContentLanguage: "uk",
+			expectedBody:            `"error_code":"auth.forbidden","message":"доступ заборонено"`,
+		},
+		{
+			name:                    "Field Errors",
+			method:                  "POST",
+			body:                    `{"name":"too long","addresses":[{"city":1}]}`,
+			acceptLanguage:          "de",
+			expectedStatus:          fiber.StatusUnprocessableEntity,
+			expectedContentLanguage: "de",
+			expectedBody: `"errors":[` +
+				`{"field":"addresses[0].city","code":"validation.type","message":"addresses[0].city muss eine Zeichenkette sein"},` +
+				`{"field":"name","code":"validation.max","message":"name darf höchstens 5 Zeichen lang sein","data":{"max":"5"}}]`,
+		},
+		{
+			name:           "Unsupported Language",
+			method:         "GET",
+			acceptLanguage: "fr",
+			expectedStatus: fiber.StatusForbidden,
+			expectedBody:   `"error_code":"auth.forbidden","message":"access denied"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := fiber.New(fiber.Config{
+				ErrorHandler:    ErrorHandler,
+				StructValidator: StructValidator(),
+			})
+			router.Use(Localize(translations))
+			router.Get("/", func(_ fiber.Ctx) error {
+				return port.ErrForbidden
+			})
+			router.Post("/", func(ctx fiber.Ctx) error {
+				req := new(validationTestBody)
+				if err := ctx.Bind().JSON(req); err != nil {
+					return newBindError(err, req)
+				}
+
+				return ctx.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(tc.method, "/", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedContentLanguage, resp.Header.Get("Content-Language"))
+			assert.Contains(t, resp.Header.Get("Vary"), "Accept-Language")
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"strings"
+
+	"app/internal/core/entity"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	loggerLocalsKey = "logger"
+
+	traceparentHeader = "traceparent"
+)
+
+// RequestLogger attaches a child logger carrying the request ID, the request line and the
+// server span, or the W3C trace context when not traced, to the request locals and to the
+// request context passed to the services, so that their logs, SQL logs included, are
+// correlated with the request. Once the request is answered, the template of the matched route
+// is added for the logs written afterwards, the access and error logs among them.
+func RequestLogger(logger *zerolog.Logger) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		logCtx := logger.With().
+			Str("request_id", requestid.FromContext(ctx)).
+			Str("method", ctx.Method()).
+			Str("path", ctx.Path())
+
+		if spanCtx := trace.SpanContextFromContext(ctx.Context()); spanCtx.IsValid() {
+			logCtx = logCtx.Str("trace_id", spanCtx.TraceID().String()).Str("span_id", spanCtx.SpanID().String())
+		} else if traceID, spanID, ok := parseTraceparent(ctx.Get(traceparentHeader)); ok {
+			logCtx = logCtx.Str("trace_id", traceID).Str("span_id", spanID)
+		}
+
+		setRequestLogger(ctx, logCtx.Logger())
+
+		err := ctx.Next()
+
+		if ctx.Matched() {
+			if logger := RequestLoggerFromCtx(ctx); logger != nil {
+				setRequestLogger(ctx, logger.With().Str("route", ctx.FullPath()).Logger())
+			}
+		}
+
+		return err
+	}
+}
+
+// RequestLoggerFromCtx returns the logger of the request, or nil outside of RequestLogger.
+func RequestLoggerFromCtx(ctx fiber.Ctx) *zerolog.Logger {
+	logger, _ := ctx.Locals(loggerLocalsKey).(*zerolog.Logger)
+
+	return logger
+}
+
+// withPrincipalLogger adds the authenticated principal to the logger of the request.
+func withPrincipalLogger(ctx fiber.Ctx, principal *entity.Principal) {
+	logger := RequestLoggerFromCtx(ctx)
+	if logger == nil {
+		return
+	}
+
+	setRequestLogger(ctx, logger.With().
+		Str("principal", string(principal.Kind)).
+		Str("user_id", principal.UserID.String()).
+		Logger())
+}
+
+func setRequestLogger(ctx fiber.Ctx, logger zerolog.Logger) {
+	ctx.Locals(loggerLocalsKey, &logger)
+	ctx.SetContext(logger.WithContext(ctx.Context()))
+}
+
+// parseTraceparent returns the trace and parent span IDs of a version 00 traceparent header.
+func parseTraceparent(header string) (traceID, spanID string, ok bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return "", "", false
+	}
+
+	if strings.Trim(parts[1], "0") == "" || strings.Trim(parts[2], "0") == "" {
+		return "", "", false
+	}
+
+	if !isLowerHex(parts[1]) || !isLowerHex(parts[2]) {
+		return "", "", false
+	}
+
+	return parts[1], parts[2], true
+}
+
+func isLowerHex(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && (r < 'a' || r > 'f')
+	}) == -1
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"app/internal/core/entity"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogger(t *testing.T) {
+	const (
+		requestID   = "0199a3c4-5b6e-7f80-9a1b-2c3d4e5f6a7b"
+		traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	)
+
+	userID := uuid.MustParse("0199a3c4-5b6e-7f80-9a1b-2c3d4e5f6a7c")
+
+	testCases := []struct {
+		name           string
+		traceparent    string
+		principal      *entity.Principal
+		expectedFields map[string]any
+	}{
+		{
+			name: "Anonymous",
+			expectedFields: map[string]any{
+				"request_id": requestID, "method": "GET", "path": "/users", "message": "service log",
+			},
+		},
+		{
+			name:        "Traced And Authenticated",
+			traceparent: traceparent,
+			principal:   &entity.Principal{Kind: entity.PrincipalKindUser, UserID: userID},
+			expectedFields: map[string]any{
+				"request_id": requestID, "method": "GET", "path": "/users", "message": "service log",
+				"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736", "span_id": "00f067aa0ba902b7",
+				"principal": "user", "user_id": userID.String(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			logger := zerolog.New(&buf)
+
+			router := fiber.New()
+			router.Use(requestid.New())
+			router.Use(RequestLogger(&logger))
+			router.Get("/users", func(ctx fiber.Ctx) error {
+				if tc.principal != nil {
+					withPrincipalLogger(ctx, tc.principal)
+				}
+
+				// services log with the logger of the request context
+				zerolog.Ctx(ctx.Context()).Info().Msg("service log")
+
+				return ctx.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/users", nil)
+			req.Header.Set("X-Request-ID", requestID)
+
+			if tc.traceparent != "" {
+				req.Header.Set("traceparent", tc.traceparent)
+			}
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+
+			delete(fields, "level")
+			assert.Equal(t, tc.expectedFields, fields)
+		})
+	}
+}
+
+func TestRequestLogger_Route(t *testing.T) {
+	testCases := []struct {
+		name          string
+		target        string
+		expectedRoute any
+	}{
+		{name: "Matched", target: "/users/0199a3c4-5b6e-7f80-9a1b-2c3d4e5f6a7c", expectedRoute: "/users/:id"},
+		{name: "Unmatched", target: "/unknown"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			logger := zerolog.New(&buf)
+
+			router := fiber.New()
+			router.Use(func(ctx fiber.Ctx) error {
+				err := ctx.Next()
+
+				// the access log is written with the request logger once the request is answered
+				RequestLoggerFromCtx(ctx).Info().Msg("access log")
+
+				return err
+			})
+			router.Use(RequestLogger(&logger))
+			router.Get("/users/:id", func(ctx fiber.Ctx) error {
+				return ctx.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := router.Test(httptest.NewRequest("GET", tc.target, nil))
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+
+			assert.Equal(t, tc.target, fields["path"])
+			assert.Equal(t, tc.expectedRoute, fields["route"])
+		})
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	testCases := []struct {
+		name            string
+		header          string
+		expectedTraceID string
+		expectedSpanID  string
+		expectedOK      bool
+	}{
+		{
+			name:            "Valid",
+			header:          "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expectedTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			expectedSpanID:  "00f067aa0ba902b7",
+			expectedOK:      true,
+		},
+		{name: "Empty", header: ""},
+		{name: "Unknown Version", header: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "Zero Trace ID", header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "Upper Case", header: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		{name: "Short Span ID", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa-01"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			traceID, spanID, ok := parseTraceparent(tc.header)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedTraceID, traceID)
+			assert.Equal(t, tc.expectedSpanID, spanID)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"app/internal/core/port"
+	"app/pkg/httpserver"
+
+	fiberzerolog "github.com/gofiber/contrib/v3/zerolog"
+	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	metrics, err := httpserver.NewMetrics(registry, ErrorCode)
+	require.NoError(t, err)
+
+	accessLogger := zerolog.Nop()
+
+	router := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	router.Use(metrics.Handler())
+	// the access log answers the errors, as in the server
+	router.Use(fiberzerolog.New(fiberzerolog.Config{Logger: &accessLogger}))
+	router.Get("/users/:id", func(ctx fiber.Ctx) error {
+		if ctx.Params("id") == "0" {
+			return port.ErrUserNotFound
+		}
+
+		return ctx.SendString("john")
+	})
+	router.Post("/users", func(ctx fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusCreated)
+	})
+
+	for _, req := range []struct{ method, path, body string }{
+		{method: "GET", path: "/users/1"},
+		{method: "GET", path: "/users/2"},
+		{method: "GET", path: "/users/0"},
+		{method: "POST", path: "/users", body: `{"name":"john"}`},
+		{method: "GET", path: "/unknown/42"},
+	} {
+		resp, err := router.Test(httptest.NewRequest(req.method, req.path, strings.NewReader(req.body)))
+		require.NoError(t, err)
+
+		_ = resp.Body.Close()
+	}
+
+	expected := `
+# HELP http_server_requests_total Number of HTTP requests served.
+# TYPE http_server_requests_total counter
+http_server_requests_total{method="GET",route="/users/:id",status_class="2xx"} 2
+http_server_requests_total{method="GET",route="/users/:id",status_class="4xx"} 1
+http_server_requests_total{method="GET",route="unmatched",status_class="4xx"} 1
+http_server_requests_total{method="POST",route="/users",status_class="2xx"} 1
+# HELP http_server_error_codes_total Number of HTTP requests answered with an application error code.
+# TYPE http_server_error_codes_total counter
+http_server_error_codes_total{code="user.not_found",method="GET",route="/users/:id"} 1
+# HELP http_server_requests_in_flight Number of HTTP requests being served.
+# TYPE http_server_requests_in_flight gauge
+http_server_requests_in_flight 0
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"http_server_requests_total", "http_server_error_codes_total", "http_server_requests_in_flight"))
+
+	assert.Equal(t, 4, testutil.CollectAndCount(registry, "http_server_request_duration_seconds"))
+}
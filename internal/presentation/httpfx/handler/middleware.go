@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	bearerScheme = "Bearer"
+	apiKeyScheme = "ApiKey"
+	apiKeyHeader = "X-API-Key"
+	csrfHeader   = "X-CSRF-Token"
+
+	sessionCookie = "session"
+	csrfCookie    = "csrf_token"
+
+	principalLocalsKey = "principal"
+)
+
+// Authenticate requires an API key, sent in the X-API-Key header or with the ApiKey
+// authorization scheme, a bearer access token or else a session cookie. The resolved
+// principal is stored in the request locals and in the request context passed to the services.
+func (h *Handler) Authenticate(ctx fiber.Ctx) error {
+	var (
+		principal *entity.Principal
+		err       error
+	)
+
+	key, token, session := apiKey(ctx), bearerToken(ctx), ctx.Cookies(sessionCookie)
+
+	switch {
+	case key != "":
+		principal, err = h.app.APIKeyService.Authenticate(ctx.Context(), key)
+	case token == "" && session != "":
+		principal, err = h.authenticateSession(ctx, session)
+		if errors.Is(err, port.ErrInvalidCSRFToken) {
+			return fmt.Errorf("authenticate: %w", err)
+		}
+	default:
+		principal, err = h.app.AuthService.Authenticate(ctx.Context(), token)
+	}
+
+	if err != nil {
+		setAuthenticateChallenge(ctx, err)
+
+		return fmt.Errorf("authenticate: %w", err)
+	}
+
+	ctx.Locals(principalLocalsKey, principal)
+	withPrincipalLogger(ctx, principal)
+	ctx.SetContext(entity.ContextWithPrincipal(ctx.Context(), principal))
+
+	return ctx.Next()
+}
+
+// Authorize declares the permissions a route requires, so requests are rejected
+// before their payload is read. The services enforce the same permissions themselves.
+// It must be registered after Authenticate.
+func (h *Handler) Authorize(permissions ...entity.Permission) fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		for _, permission := range permissions {
+			if err := h.app.Authorizer.Authorize(ctx.Context(), permission); err != nil {
+				return fmt.Errorf("authorize: %w", err)
+			}
+		}
+
+		return ctx.Next()
+	}
+}
+
+// authenticateSession resolves the principal of a session cookie. Requests with unsafe
+// methods must echo the CSRF token of the session in the X-CSRF-Token header.
+func (h *Handler) authenticateSession(ctx fiber.Ctx, token string) (*entity.Principal, error) {
+	session, err := h.app.SessionService.Authenticate(ctx.Context(), token)
+	if err != nil {
+		if errors.Is(err, port.ErrInvalidSession) || errors.Is(err, port.ErrSessionExpired) {
+			clearSessionCookies(ctx)
+		}
+
+		return nil, err
+	}
+
+	if !fiber.IsMethodSafe(ctx.Method()) && !session.MatchesCSRFToken(ctx.Get(csrfHeader)) {
+		return nil, port.ErrInvalidCSRFToken
+	}
+
+	return session.Principal(), nil
+}
+
+func principalFromCtx(ctx fiber.Ctx) (*entity.Principal, error) {
+	principal, ok := ctx.Locals(principalLocalsKey).(*entity.Principal)
+	if !ok {
+		return nil, port.ErrUnauthenticated
+	}
+
+	return principal, nil
+}
+
+func bearerToken(ctx fiber.Ctx) string {
+	return authorizationCredentials(ctx, bearerScheme)
+}
+
+func apiKey(ctx fiber.Ctx) string {
+	if key := ctx.Get(apiKeyHeader); key != "" {
+		return key
+	}
+
+	return authorizationCredentials(ctx, apiKeyScheme)
+}
+
+// authorizationCredentials returns the credentials of the Authorization header
+// when it uses the given scheme.
+func authorizationCredentials(ctx fiber.Ctx, scheme string) string {
+	headerScheme, credentials, ok := strings.Cut(ctx.Get(fiber.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(headerScheme, scheme) {
+		return ""
+	}
+
+	return strings.TrimSpace(credentials)
+}
+
+// setAuthenticateChallenge sets the challenge of a rejected request, as RFC 6750 defines it for bearer tokens.
+func setAuthenticateChallenge(ctx fiber.Ctx, err error) {
+	challenge := bearerScheme
+
+	switch {
+	case errors.Is(err, port.ErrTokenExpired):
+		challenge += ` error="invalid_token", error_description="token expired"`
+	case errors.Is(err, port.ErrInvalidToken):
+		challenge += ` error="invalid_token"`
+	case errors.Is(err, port.ErrInvalidAPIKey), errors.Is(err, port.ErrAPIKeyExpired):
+		challenge = apiKeyScheme
+	}
+
+	ctx.Set(fiber.HeaderWWWAuthenticate, challenge)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+
+	domainErrors "app/internal/core/error"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+)
+
+const (
+	problemMediaType = "application/problem+json"
+
+	// problemTypePrefix prefixes the error code of registered errors to build the problem type,
+	// the codes are listed in docs/errors.md.
+	problemTypePrefix = "urn:gohex:error:"
+	problemTypeBlank  = "about:blank"
+	// problemInstancePrefix prefixes the request ID to build the URI of the occurrence.
+	problemInstancePrefix = "urn:request:"
+)
+
+// Problem is an RFC 9457 problem details object. Extensions are serialized as top-level members.
+type Problem struct {
+	Type       string              `json:"type" example:"urn:gohex:error:user.not_found"`
+	Title      string              `json:"title" example:"Not Found"`
+	Status     int                 `json:"status" example:"404"`
+	Detail     string              `json:"detail,omitempty" example:"user not found"`
+	Instance   string              `json:"instance,omitempty" example:"urn:request:0199a3c4-5b6e-7f80-9a1b-2c3d4e5f6a7b"`
+	Code       string              `json:"code,omitempty" example:"user.not_found"`
+	Errors     []ProblemFieldError `json:"errors,omitempty"`
+	Extensions map[string]any      `json:"-"`
+}
+
+// ProblemFieldError describes an invalid input field of a validation problem.
+type ProblemFieldError struct {
+	Field  string         `json:"field" example:"username"`
+	Detail string         `json:"detail" example:"username is too short"`
+	Code   string         `json:"code,omitempty" example:"user.username_too_short"`
+	Data   map[string]any `json:"data,omitempty"`
+}
+
+// problemMembers are the members extensions may not override.
+var problemMembers = map[string]struct{}{
+	"type": {}, "title": {}, "status": {}, "detail": {}, "instance": {}, "code": {}, "errors": {},
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+
+	data, err := json.Marshal((*problem)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]any, len(p.Extensions))
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	for key, val := range p.Extensions {
+		if _, reserved := problemMembers[key]; !reserved {
+			members[key] = val
+		}
+	}
+
+	return json.Marshal(members)
+}
+
+// acceptsProblem reports whether the client prefers problem details over plain JSON errors.
+func acceptsProblem(ctx fiber.Ctx) bool {
+	return ctx.Accepts(fiber.MIMEApplicationJSON, problemMediaType) == problemMediaType
+}
+
+func newProblem(ctx fiber.Ctx, clientErr *ClientError, fieldErrs []FieldError) *Problem {
+	problem := &Problem{
+		Type:     problemTypeBlank,
+		Title:    http.StatusText(clientErr.StatusCode),
+		Status:   clientErr.StatusCode,
+		Detail:   clientErr.Message,
+		Instance: problemInstance(ctx),
+		Code:     clientErr.ErrorCode,
+	}
+
+	// the title is the one of the status, as the detail only is localized
+	if def, ok := domainErrors.Lookup(clientErr.ErrorCode); ok {
+		problem.Type = problemTypePrefix + def.Code
+	}
+
+	for _, fieldErr := range fieldErrs {
+		problem.Errors = append(problem.Errors, ProblemFieldError{
+			Field:  fieldErr.Field,
+			Detail: fieldErr.Message,
+			Code:   fieldErr.Code,
+			Data:   fieldErr.Data,
+		})
+	}
+
+	if len(clientErr.Data) > 0 {
+		problem.Extensions = maps.Clone(clientErr.Data)
+	}
+
+	return problem
+}
+
+// newInternalProblem describes an unhandled error without leaking its details,
+// the request ID lets the client refer to the logged error.
+func newInternalProblem(ctx fiber.Ctx) *Problem {
+	return &Problem{
+		Type:     problemTypeBlank,
+		Title:    http.StatusText(http.StatusInternalServerError),
+		Status:   http.StatusInternalServerError,
+		Instance: problemInstance(ctx),
+	}
+}
+
+// problemInstance identifies the occurrence by the URI of the request ID, empty without one.
+func problemInstance(ctx fiber.Ctx) string {
+	requestID := requestid.FromContext(ctx)
+	if requestID == "" {
+		return ""
+	}
+
+	return problemInstancePrefix + requestID
+}
+
+func writeProblem(ctx fiber.Ctx, problem *Problem) error {
+	return ctx.Status(problem.Status).JSON(problem, problemMediaType)
+}
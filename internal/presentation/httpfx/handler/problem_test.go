@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/core/port"
+	"app/pkg/errtrace"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHandler_Problem(t *testing.T) {
+	const requestID = "0199a3c4-5b6e-7f80-9a1b-2c3d4e5f6a7b"
+
+	testCases := []struct {
+		name                string
+		err                 error
+		accept              string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        map[string]any
+	}{
+		{
+			name:                "Registered Error",
+			err:                 port.ErrForbidden.With(domainErrors.Arg("permission", entity.PermissionUsersRead)),
+			accept:              "application/problem+json",
+			expectedStatus:      fiber.StatusForbidden,
+			expectedContentType: "application/problem+json",
+			expectedBody: map[string]any{
+				"type":       "urn:gohex:error:auth.forbidden",
+				"title":      "Forbidden",
+				"status":     float64(fiber.StatusForbidden),
+				"detail":     "access denied",
+				"instance":   "urn:request:" + requestID,
+				"code":       "auth.forbidden",
+				"permission": "users:read",
+			},
+		},
+		{
+			name:                "Validation Error",
+			err:                 entity.ErrUsernameTooShort.With(domainErrors.Arg("min", 3)),
+			accept:              "application/json;q=0.5, application/problem+json",
+			expectedStatus:      fiber.StatusUnprocessableEntity,
+			expectedContentType: "application/problem+json",
+			expectedBody: map[string]any{
+				"type":     "urn:gohex:error:user.username_too_short",
+				"title":    "Unprocessable Entity",
+				"status":   float64(fiber.StatusUnprocessableEntity),
+				"detail":   "username is too short",
+				"instance": "urn:request:" + requestID,
+				"code":     "user.username_too_short",
+				"min":      float64(3),
+				"errors": []any{map[string]any{
+					"field":  "username",
+					"detail": "username is too short",
+					"code":   "user.username_too_short",
+					"data":   map[string]any{"min": float64(3)},
+				}},
+			},
+		},
+		{
+			name:                "Client Error",
+			err:                 newBadRequest("malformed body"),
+			accept:              "application/problem+json",
+			expectedStatus:      fiber.StatusBadRequest,
+			expectedContentType: "application/problem+json",
+			expectedBody: map[string]any{
+				"type":     "about:blank",
+				"title":    "Bad Request",
+				"status":   float64(fiber.StatusBadRequest),
+				"detail":   "malformed body",
+				"instance": "urn:request:" + requestID,
+			},
+		},
+		{
+			name:                "Plain JSON By Default",
+			err:                 port.ErrUserNotFound,
+			accept:              "*/*",
+			expectedStatus:      fiber.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedBody: map[string]any{
+				"code":       float64(fiber.StatusNotFound),
+				"error_code": "user.not_found",
+				"message":    "user not found",
+			},
+		},
+		{
+			name:                "Unhandled Error",
+			err:                 errors.New("connection refused"),
+			accept:              "application/json",
+			expectedStatus:      fiber.StatusInternalServerError,
+			expectedContentType: "application/problem+json",
+			expectedBody: map[string]any{
+				"type":     "about:blank",
+				"title":    "Internal Server Error",
+				"status":   float64(fiber.StatusInternalServerError),
+				"instance": "urn:request:" + requestID,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := fiber.New(fiber.Config{
+				ErrorHandler: ErrorHandler,
+			})
+			router.Use(requestid.New())
+			router.Get("/", func(fiber.Ctx) error {
+				return tc.err
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Accept", tc.accept)
+			req.Header.Set("X-Request-ID", requestID)
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Contains(t, resp.Header.Get("Content-Type"), tc.expectedContentType)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(bodyBytes, &body))
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+
+func TestErrorHandler_InternalError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		errorHandler   fiber.ErrorHandler
+		expectedCauses any
+		expectStack    bool
+	}{
+		{
+			name:         "Hidden",
+			errorHandler: ErrorHandler,
+		},
+		{
+			name:           "Debug",
+			errorHandler:   DebugErrorHandler,
+			expectedCauses: []any{"find user: connection refused", "connection refused"},
+			expectStack:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			internalErr := errtrace.Errorf("find user: %w", errors.New("connection refused"))
+
+			var loggedErr error
+
+			router := fiber.New(fiber.Config{
+				ErrorHandler: tc.errorHandler,
+			})
+			router.Use(func(ctx fiber.Ctx) error {
+				if err := ctx.Next(); err != nil {
+					_ = ctx.App().ErrorHandler(ctx, err)
+				}
+
+				loggedErr = InternalError(ctx)
+
+				return nil
+			})
+			router.Get("/", func(fiber.Ctx) error {
+				return internalErr
+			})
+
+			resp, err := router.Test(httptest.NewRequest("GET", "/", nil))
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+			assert.Equal(t, internalErr, loggedErr)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(bodyBytes, &body))
+			assert.Equal(t, tc.expectedCauses, body["causes"])
+			assert.Equal(t, tc.expectStack, body["stack"] != nil)
+		})
+	}
+}
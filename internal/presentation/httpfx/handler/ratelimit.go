@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/core/port"
+	"app/pkg/ratelimit"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+)
+
+const (
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+	rateLimitPolicyHeader    = "RateLimit-Policy"
+)
+
+// RateLimitKey returns the subject the requests are counted by.
+type RateLimitKey func(ctx fiber.Ctx) string
+
+// RateLimitByIP counts the requests by client IP.
+func RateLimitByIP(ctx fiber.Ctx) string {
+	return "ip:" + ctx.IP()
+}
+
+// RateLimitByPrincipal counts the requests by authenticated user, by IP before authentication.
+func RateLimitByPrincipal(ctx fiber.Ctx) string {
+	principal, err := principalFromCtx(ctx)
+	if err != nil {
+		return RateLimitByIP(ctx)
+	}
+
+	return "user:" + principal.UserID.String()
+}
+
+// RateLimitByAPIKey counts the requests authenticated with an API key by key, the other ones
+// by principal.
+func RateLimitByAPIKey(ctx fiber.Ctx) string {
+	principal, err := principalFromCtx(ctx)
+	if err != nil || principal.Kind != entity.PrincipalKindAPIKey {
+		return RateLimitByPrincipal(ctx)
+	}
+
+	prefix, _, _ := entity.ParseAPIKey(apiKey(ctx))
+
+	return "api_key:" + prefix
+}
+
+// RateLimiter limits the requests of the routes it is registered on, keyed by a RateLimitKey.
+type RateLimiter struct {
+	store   port.RateLimitStore
+	limit   ratelimit.Limit
+	enabled bool
+	now     func() time.Time
+}
+
+func NewRateLimiter(cfg ratelimit.Config, store port.RateLimitStore) (*RateLimiter, error) {
+	if err := cfg.Limit().Validate(); err != nil {
+		return nil, fmt.Errorf("default rate limit: %w", err)
+	}
+
+	return &RateLimiter{store: store, limit: cfg.Limit(), enabled: cfg.Enabled, now: time.Now}, nil
+}
+
+// Limit limits the requests with the default limit. The name separates the counts of the
+// routes limited apart.
+func (l *RateLimiter) Limit(name string, key RateLimitKey) fiber.Handler {
+	return l.LimitWith(name, key, l.limit)
+}
+
+// LimitWith limits the requests with a limit overriding the default one, the algorithm
+// of the default limit being used when the override has none. It panics on an invalid
+// limit, which is declared along with the routes.
+func (l *RateLimiter) LimitWith(name string, key RateLimitKey, limit ratelimit.Limit) fiber.Handler {
+	if limit.Algorithm == "" {
+		limit.Algorithm = l.limit.Algorithm
+	}
+
+	if err := limit.Validate(); err != nil {
+		panic(fmt.Sprintf("rate limit %s: %v", name, err))
+	}
+
+	return func(ctx fiber.Ctx) error {
+		if !l.enabled {
+			return ctx.Next()
+		}
+
+		result, err := l.store.Take(ctx.Context(), name+":"+key(ctx), limit, l.now())
+		if err != nil {
+			// the API stays available when the store is not
+			zerolog.Ctx(ctx.Context()).Error().Err(err).Str("limit", name).Msg("rate limit")
+
+			return ctx.Next()
+		}
+
+		setRateLimitHeaders(ctx, limit, result)
+
+		if !result.Allowed {
+			retryAfter := seconds(result.RetryAfter)
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+
+			return port.ErrRateLimitExceeded.With(domainErrors.Arg("retry_after", retryAfter))
+		}
+
+		return ctx.Next()
+	}
+}
+
+// setRateLimitHeaders sets the RateLimit headers of the IETF draft. A request limited more than
+// once reports the limit with the fewest remaining requests.
+func setRateLimitHeaders(ctx fiber.Ctx, limit ratelimit.Limit, result ratelimit.Result) {
+	if current := ctx.GetRespHeader(rateLimitRemainingHeader); current != "" {
+		if remaining, err := strconv.Atoi(current); err == nil && remaining <= result.Remaining {
+			return
+		}
+	}
+
+	ctx.Set(rateLimitLimitHeader, strconv.Itoa(result.Limit))
+	ctx.Set(rateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+	ctx.Set(rateLimitResetHeader, strconv.Itoa(seconds(result.Reset)))
+	ctx.Set(rateLimitPolicyHeader, strconv.Itoa(limit.Requests)+";w="+strconv.Itoa(seconds(limit.Period)))
+}
+
+// seconds rounds a duration up to whole seconds, as the headers expect.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/locales"
+	"app/pkg/i18n"
+	"app/pkg/ratelimit"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, ratelimit.Limit, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func (failingRateLimitStore) DeleteExpired(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestRateLimiter(t *testing.T) {
+	translations, err := i18n.NewBundle(i18n.Config{DefaultLocale: "en"})
+	require.NoError(t, err)
+	require.NoError(t, translations.LoadFS(locales.FS))
+
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		enabled         bool
+		store           port.RateLimitStore
+		accept          string
+		acceptLanguage  string
+		requests        int
+		expectedStatus  int
+		expectedHeaders map[string]string
+		expectedBody    string
+	}{
+		{
+			name:           "Allowed",
+			enabled:        true,
+			store:          ratelimit.NewMemoryStore(),
+			requests:       2,
+			expectedStatus: fiber.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "3",
+				"RateLimit-Remaining": "1",
+				"RateLimit-Reset":     "40",
+				"RateLimit-Policy":    "3;w=60",
+			},
+		},
+		{
+			name:           "Exceeded",
+			enabled:        true,
+			store:          ratelimit.NewMemoryStore(),
+			requests:       4,
+			expectedStatus: fiber.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "60",
+				"Retry-After":         "20",
+			},
+			expectedBody: `{"code":429,"error_code":"rate_limit.exceeded","message":"too many requests","data":{"retry_after":20}}`,
+		},
+		{
+			name:            "Exceeded Localized",
+			enabled:         true,
+			store:           ratelimit.NewMemoryStore(),
+			acceptLanguage:  "de",
+			requests:        4,
+			expectedStatus:  fiber.StatusTooManyRequests,
+			expectedHeaders: map[string]string{"Retry-After": "20"},
+			expectedBody:    `"message":"Zu viele Anfragen, bitte in 20 Sekunden erneut versuchen"`,
+		},
+		{
+			name:            "Exceeded Problem",
+			enabled:         true,
+			store:           ratelimit.NewMemoryStore(),
+			accept:          "application/problem+json",
+			requests:        4,
+			expectedStatus:  fiber.StatusTooManyRequests,
+			expectedHeaders: map[string]string{"Content-Type": "application/problem+json"},
+			expectedBody: `{"code":"rate_limit.exceeded","detail":"too many requests","retry_after":20,"status":429,` +
+				`"title":"Too Many Requests","type":"urn:gohex:error:rate_limit.exceeded"}`,
+		},
+		{
+			name:            "Disabled",
+			store:           ratelimit.NewMemoryStore(),
+			requests:        4,
+			expectedStatus:  fiber.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": ""},
+		},
+		{
+			name:            "Store Error",
+			enabled:         true,
+			store:           failingRateLimitStore{},
+			requests:        4,
+			expectedStatus:  fiber.StatusOK,
+			expectedHeaders: map[string]string{"RateLimit-Limit": ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			limiter, err := NewRateLimiter(ratelimit.Config{
+				Enabled:   tc.enabled,
+				Algorithm: ratelimit.TokenBucket,
+				Requests:  3,
+				Period:    time.Minute,
+			}, tc.store)
+			require.NoError(t, err)
+
+			limiter.now = func() time.Time { return now }
+
+			router := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			router.Use(Localize(translations))
+			router.Get("/", limiter.Limit("test", RateLimitByIP), func(ctx fiber.Ctx) error {
+				return ctx.SendString("ok")
+			})
+
+			var (
+				status  int
+				headers map[string]string
+				body    string
+			)
+
+			for range tc.requests {
+				req := httptest.NewRequest("GET", "/", nil)
+				if tc.accept != "" {
+					req.Header.Set("Accept", tc.accept)
+				}
+
+				if tc.acceptLanguage != "" {
+					req.Header.Set("Accept-Language", tc.acceptLanguage)
+				}
+
+				resp, err := router.Test(req)
+				require.NoError(t, err)
+
+				raw, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+
+				_ = resp.Body.Close()
+
+				status, body = resp.StatusCode, string(raw)
+				headers = make(map[string]string, len(tc.expectedHeaders))
+
+				for header := range tc.expectedHeaders {
+					headers[header] = resp.Header.Get(header)
+				}
+			}
+
+			assert.Equal(t, tc.expectedStatus, status)
+			assert.Equal(t, tc.expectedHeaders, headers)
+			assert.Contains(t, body, tc.expectedBody)
+		})
+	}
+}
+
+func TestRateLimiter_LowestRemaining(t *testing.T) {
+	limiter, err := NewRateLimiter(ratelimit.Config{
+		Enabled: true, Algorithm: ratelimit.TokenBucket, Requests: 100, Period: time.Minute,
+	}, ratelimit.NewMemoryStore())
+	require.NoError(t, err)
+
+	router := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	router.Use(limiter.Limit("global", RateLimitByIP))
+	router.Get("/",
+		limiter.LimitWith("strict", RateLimitByIP, ratelimit.Limit{Requests: 5, Period: time.Minute}),
+		func(ctx fiber.Ctx) error { return ctx.SendString("ok") },
+	)
+
+	resp, err := router.Test(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "5", resp.Header.Get("RateLimit-Limit"))
+	assert.Equal(t, "4", resp.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "5;w=60", resp.Header.Get("RateLimit-Policy"))
+}
+
+func TestRateLimiter_InvalidLimit(t *testing.T) {
+	_, err := NewRateLimiter(ratelimit.Config{Algorithm: "leaky_bucket", Requests: 1, Period: time.Minute}, nil)
+	require.ErrorIs(t, err, ratelimit.ErrUnknownAlgorithm)
+
+	limiter, err := NewRateLimiter(ratelimit.Config{Requests: 1, Period: time.Minute}, nil)
+	require.NoError(t, err)
+
+	assert.Panics(t, func() {
+		limiter.LimitWith("invalid", RateLimitByIP, ratelimit.Limit{Period: time.Minute})
+	})
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	userID := uuid.MustParse("0192a3b4-c5d6-7e8f-9a0b-1c2d3e4f5a6b")
+
+	testCases := []struct {
+		name              string
+		principal         *entity.Principal
+		apiKey            string
+		expectedIP        string
+		expectedPrincipal string
+		expectedAPIKey    string
+	}{
+		{
+			name:              "Anonymous",
+			expectedIP:        "ip:0.0.0.0",
+			expectedPrincipal: "ip:0.0.0.0",
+			expectedAPIKey:    "ip:0.0.0.0",
+		},
+		{
+			name:              "User",
+			principal:         &entity.Principal{Kind: entity.PrincipalKindUser, UserID: userID},
+			expectedIP:        "ip:0.0.0.0",
+			expectedPrincipal: "user:" + userID.String(),
+			expectedAPIKey:    "user:" + userID.String(),
+		},
+		{
+			name:              "API Key",
+			principal:         &entity.Principal{Kind: entity.PrincipalKindAPIKey, UserID: userID},
+			apiKey:            "gohex_0a1b2c3d.secret",
+			expectedIP:        "ip:0.0.0.0",
+			expectedPrincipal: "user:" + userID.String(),
+			expectedAPIKey:    "api_key:0a1b2c3d",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var keys [3]string
+
+			router := fiber.New()
+			router.Get("/", func(ctx fiber.Ctx) error {
+				if tc.principal != nil {
+					ctx.Locals(principalLocalsKey, tc.principal)
+				}
+
+				keys = [3]string{RateLimitByIP(ctx), RateLimitByPrincipal(ctx), RateLimitByAPIKey(ctx)}
+
+				return ctx.SendStatus(fiber.StatusNoContent)
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.apiKey != "" {
+				req.Header.Set(apiKeyHeader, tc.apiKey)
+			}
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			_ = resp.Body.Close()
+
+			assert.Equal(t, [3]string{tc.expectedIP, tc.expectedPrincipal, tc.expectedAPIKey}, keys)
+		})
+	}
+}
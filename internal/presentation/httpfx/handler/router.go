@@ -1,15 +1,54 @@
 package handler
 
 import (
+	"time"
+
 	swagger "github.com/gofiber/contrib/v3/swaggo"
 	"github.com/gofiber/fiber/v3"
 
 	_ "app/docs"
+	"app/internal/core/entity"
+	"app/pkg/health"
+	"app/pkg/i18n"
+	"app/pkg/jwt"
+	"app/pkg/ratelimit"
 )
 
-func ApplyRoutes(app *fiber.App, handler *Handler) {
+func ApplyRoutes(
+	app *fiber.App, handler *Handler, keys *jwt.Manager, translations *i18n.Bundle, checks *health.Registry,
+	limiter *RateLimiter,
+) {
+	loginLimit := limiter.LimitWith("login", RateLimitByIP, ratelimit.Limit{Requests: 10, Period: time.Minute})
+	bulkLimit := limiter.LimitWith("bulk", RateLimitByAPIKey, ratelimit.Limit{Requests: 5, Period: time.Minute})
+
+	app.Get("/livez", Livez(checks))
+	app.Get("/readyz", Readyz(checks))
+
+	app.Use(Localize(translations))
+	app.Use(limiter.Limit("global", RateLimitByIP))
+
 	app.Get("/docs/*", swagger.HandlerDefault)
+	app.Get("/.well-known/jwks.json", JWKS(keys))
+
+	app.Post("/auth/login", loginLimit, handler.Login)
+	app.Post("/auth/refresh", handler.RefreshToken)
+	app.Post("/auth/logout", handler.Logout)
+	app.Get("/auth/me", handler.Authenticate, handler.Me)
+	app.Post("/auth/session", loginLimit, handler.StartSession)
+	app.Delete("/auth/session", handler.Authenticate, handler.EndSession)
+
+	app.Post("/api-keys", handler.Authenticate, handler.CreateAPIKey)
 
 	app.Post("/users", handler.CreateUser)
-	app.Get("/users/:id", handler.GetUserByID)
+	app.Post("/users/import",
+		handler.Authenticate, bulkLimit, handler.Authorize(entity.PermissionUsersImport), handler.ImportUsers)
+	app.Get("/users",
+		handler.Authenticate, handler.Authorize(entity.PermissionUsersRead), handler.ListUsers)
+	app.Get("/users/export",
+		handler.Authenticate, bulkLimit, handler.Authorize(entity.PermissionUsersExport), handler.ExportUsers)
+	// the user service authorizes reading another user, users reading themselves without users:read
+	app.Get("/users/:id", handler.Authenticate, handler.GetUserByID)
+	app.Get("/users/:id/sessions", handler.Authenticate, handler.ListUserSessions)
+	app.Delete("/users/:id/sessions", handler.Authenticate, handler.RevokeUserSessions)
+	app.Delete("/users/:id/sessions/:session_id", handler.Authenticate, handler.RevokeUserSession)
 }
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/internal/core"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/mocks"
+	"app/locales"
+	"app/pkg/health"
+	"app/pkg/i18n"
+	"app/pkg/ratelimit"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplyRoutes_GetUser(t *testing.T) {
+	translations, err := i18n.NewBundle(i18n.Config{DefaultLocale: "en"})
+	require.NoError(t, err)
+	require.NoError(t, translations.LoadFS(locales.FS))
+
+	checks, err := health.NewRegistry(health.Config{})
+	require.NoError(t, err)
+
+	limiter, err := NewRateLimiter(ratelimit.Config{Requests: 1, Period: time.Minute}, nil)
+	require.NoError(t, err)
+
+	self := &entity.User{ID: uuid.Must(uuid.NewV7()), Username: "self"}
+	otherID := uuid.Must(uuid.NewV7())
+	// an API key without the users:read scope
+	principal := &entity.Principal{Kind: entity.PrincipalKindAPIKey, UserID: self.ID}
+
+	testCases := []struct {
+		name           string
+		userID         uuid.UUID
+		setupMock      func(m *mocks.MockUserService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "Self Without Permission",
+			userID: self.ID,
+			setupMock: func(m *mocks.MockUserService) {
+				m.EXPECT().GetByID(gomock.Any(), self.ID).Return(self, nil).Times(1)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   `"username":"self"`,
+		},
+		{
+			name:   "Other Without Permission",
+			userID: otherID,
+			setupMock: func(m *mocks.MockUserService) {
+				m.EXPECT().GetByID(gomock.Any(), otherID).Return(nil, port.ErrForbidden).Times(1)
+			},
+			expectedStatus: fiber.StatusForbidden,
+			expectedBody:   `"message":"access denied"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAPIKeyService := mocks.NewMockAPIKeyService(ctrl)
+			mockAPIKeyService.EXPECT().Authenticate(gomock.Any(), "gohex_abc.secret").Return(principal, nil).Times(1)
+
+			mockUserService := mocks.NewMockUserService(ctrl)
+			tc.setupMock(mockUserService)
+
+			// the route leaves the authorization to the user service
+			handler := NewHandler(&core.Application{
+				UserService:   mockUserService,
+				Authorizer:    mocks.NewMockAuthorizer(ctrl),
+				APIKeyService: mockAPIKeyService,
+			})
+
+			router := fiber.New(fiber.Config{ErrorHandler: ErrorHandler, StructValidator: StructValidator()})
+			ApplyRoutes(router, handler, nil, translations, checks, limiter)
+
+			req := httptest.NewRequest("GET", "/users/"+tc.userID.String(), nil)
+			req.Header.Set("X-API-Key", "gohex_abc.secret")
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
+		})
+	}
+}
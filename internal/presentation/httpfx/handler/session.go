@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/types"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type startSessionResponse struct {
+	CSRFToken string    `json:"csrf_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type sessionResponse struct {
+	ID         types.ID  `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func newSessionResponse(session *entity.Session) sessionResponse {
+	return sessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+type userSessionsRequest struct {
+	UserID string `uri:"id" validate:"required,uuid"`
+}
+
+type userSessionRequest struct {
+	UserID    string `uri:"id" validate:"required,uuid"`
+	SessionID string `uri:"session_id" validate:"required,uuid"`
+}
+
+// StartSession
+//
+//	@Summary		Start a session
+//	@Description	Log in for browsers: the session is kept in an HttpOnly cookie. Requests with unsafe
+//	@Description	methods must send the returned CSRF token, also set in the csrf_token cookie, in the X-CSRF-Token header.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		loginRequest	true	"Credentials"
+//	@Success		200		{object}	startSessionResponse
+//	@Failure		400		{object}	ClientError
+//	@Failure		401		{object}	ClientError
+//	@Router			/auth/session [post]
+func (h *Handler) StartSession(ctx fiber.Ctx) error {
+	req := new(loginRequest)
+	if err := ctx.Bind().JSON(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	created, err := h.app.SessionService.Start(ctx.Context(), dto.StartSession{
+		Login: dto.Login{
+			Username: req.Username,
+			Password: req.Password,
+		},
+		UserAgent: ctx.Get(fiber.HeaderUserAgent),
+		IP:        ctx.IP(),
+	})
+	if err != nil {
+		return fmt.Errorf("start session: %w", err)
+	}
+
+	setSessionCookies(ctx, created.Token, created.Session.CSRFToken)
+
+	return ctx.JSON(startSessionResponse{
+		CSRFToken: created.Session.CSRFToken,
+		ExpiresAt: created.Session.ExpiresAt,
+	})
+}
+
+// EndSession
+//
+//	@Summary		End the session
+//	@Description	Log out the session of the cookie.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			X-CSRF-Token	header		string	true	"CSRF token"
+//	@Success		200				{object}	MessageResponse
+//	@Failure		401				{object}	ClientError
+//	@Failure		403				{object}	ClientError
+//	@Router			/auth/session [delete]
+func (h *Handler) EndSession(ctx fiber.Ctx) error {
+	if err := h.app.SessionService.End(ctx.Context(), ctx.Cookies(sessionCookie)); err != nil {
+		return fmt.Errorf("end session: %w", err)
+	}
+
+	clearSessionCookies(ctx)
+
+	return ctx.JSON(MessageResponse{Message: "ok"})
+}
+
+// ListUserSessions
+//
+//	@Summary		List sessions of a user
+//	@Description	List the active sessions of a user. Managing other users' sessions requires the sessions:manage permission.
+//	@Tags			sessions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{array}		sessionResponse
+//	@Failure		400	{object}	ClientError
+//	@Failure		401	{object}	ClientError
+//	@Failure		403	{object}	ClientError
+//	@Router			/users/{id}/sessions [get]
+func (h *Handler) ListUserSessions(ctx fiber.Ctx) error {
+	req := new(userSessionsRequest)
+	if err := ctx.Bind().URI(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	sessions, err := h.app.SessionService.List(ctx.Context(), parseID(req.UserID))
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+
+	resp := make([]sessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, newSessionResponse(session))
+	}
+
+	return ctx.JSON(resp)
+}
+
+// RevokeUserSession
+//
+//	@Summary	Revoke a session of a user
+//	@Tags		sessions
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		id			path		string	true	"User ID"
+//	@Param		session_id	path		string	true	"Session ID"
+//	@Success	200			{object}	MessageResponse
+//	@Failure	400			{object}	ClientError
+//	@Failure	401			{object}	ClientError
+//	@Failure	403			{object}	ClientError
+//	@Router		/users/{id}/sessions/{session_id} [delete]
+func (h *Handler) RevokeUserSession(ctx fiber.Ctx) error {
+	req := new(userSessionRequest)
+	if err := ctx.Bind().URI(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	if err := h.app.SessionService.Revoke(ctx.Context(), parseID(req.UserID), parseID(req.SessionID)); err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+
+	return ctx.JSON(MessageResponse{Message: "ok"})
+}
+
+// RevokeUserSessions
+//
+//	@Summary	Revoke all sessions of a user
+//	@Tags		sessions
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string	true	"User ID"
+//	@Success	200	{object}	MessageResponse
+//	@Failure	400	{object}	ClientError
+//	@Failure	401	{object}	ClientError
+//	@Failure	403	{object}	ClientError
+//	@Router		/users/{id}/sessions [delete]
+func (h *Handler) RevokeUserSessions(ctx fiber.Ctx) error {
+	req := new(userSessionsRequest)
+	if err := ctx.Bind().URI(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	if err := h.app.SessionService.RevokeAll(ctx.Context(), parseID(req.UserID)); err != nil {
+		return fmt.Errorf("revoke sessions: %w", err)
+	}
+
+	return ctx.JSON(MessageResponse{Message: "ok"})
+}
+
+// setSessionCookies sets the session cookie, which scripts cannot read, and the CSRF cookie,
+// which scripts of the same site read to send the token back. Both only live as long as the
+// browser session, the server enforces the actual expiration.
+func setSessionCookies(ctx fiber.Ctx, token, csrfToken string) {
+	ctx.Cookie(&fiber.Cookie{
+		Name:        sessionCookie,
+		Value:       token,
+		Path:        "/",
+		Secure:      true,
+		HTTPOnly:    true,
+		SameSite:    fiber.CookieSameSiteLaxMode,
+		SessionOnly: true,
+	})
+	ctx.Cookie(&fiber.Cookie{
+		Name:        csrfCookie,
+		Value:       csrfToken,
+		Path:        "/",
+		Secure:      true,
+		SameSite:    fiber.CookieSameSiteLaxMode,
+		SessionOnly: true,
+	})
+}
+
+func clearSessionCookies(ctx fiber.Ctx) {
+	ctx.ClearCookie(sessionCookie, csrfCookie)
+}
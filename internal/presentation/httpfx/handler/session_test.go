@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/internal/core"
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/core/port"
+	"app/internal/mocks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSessionHandler_StartSession(t *testing.T) {
+	input := dto.StartSession{
+		Login:     dto.Login{Username: "john", Password: "secret-password"},
+		UserAgent: "test-agent",
+		IP:        "0.0.0.0",
+	}
+	created := &dto.CreatedSession{
+		Session: &entity.Session{
+			ID:        uuid.Must(uuid.NewV7()),
+			CSRFToken: "csrf-token",
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+		Token: "session-token",
+	}
+
+	testCases := []struct {
+		name            string
+		setupMock       func(m *mocks.MockSessionService)
+		expectedStatus  int
+		expectedBody    string
+		expectedCookies []string
+	}{
+		{
+			name: "Success",
+			setupMock: func(m *mocks.MockSessionService) {
+				m.EXPECT().Start(gomock.Any(), input).Return(created, nil).Times(1)
+			},
+			expectedStatus:  fiber.StatusOK,
+			expectedBody:    `"csrf_token":"csrf-token"`,
+			expectedCookies: []string{"session=session-token", "csrf_token=csrf-token"},
+		},
+		{
+			name: "Invalid Credentials",
+			setupMock: func(m *mocks.MockSessionService) {
+				m.EXPECT().Start(gomock.Any(), input).Return(nil, port.ErrInvalidCredentials).Times(1)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+			expectedBody:   `"message":"invalid username or password"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSessionService := mocks.NewMockSessionService(ctrl)
+			tc.setupMock(mockSessionService)
+
+			handler := NewHandler(&core.Application{SessionService: mockSessionService})
+
+			router := fiber.New(fiber.Config{
+				ErrorHandler:    ErrorHandler,
+				StructValidator: StructValidator(),
+			})
+			router.Post("/auth/session", handler.StartSession)
+
+			body := []byte(`{"username":"john","password":"secret-password"}`)
+			req := httptest.NewRequest("POST", "/auth/session", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("User-Agent", "test-agent")
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
+
+			cookies := resp.Header.Values("Set-Cookie")
+			require.Len(t, cookies, len(tc.expectedCookies))
+			for i, cookie := range tc.expectedCookies {
+				assert.Contains(t, cookies[i], cookie)
+			}
+			if len(cookies) > 0 {
+				assert.Contains(t, cookies[0], "HttpOnly")
+				assert.NotContains(t, cookies[1], "HttpOnly")
+			}
+		})
+	}
+}
+
+func TestHandler_AuthenticateSession(t *testing.T) {
+	userID := uuid.Must(uuid.NewV7())
+	session := &entity.Session{ID: uuid.Must(uuid.NewV7()), UserID: userID, CSRFToken: "csrf-token"}
+
+	testCases := []struct {
+		name           string
+		method         string
+		csrfToken      string
+		setupMock      func(m *mocks.MockSessionService)
+		expectedStatus int
+		expectedBody   string
+		clearsCookies  bool
+	}{
+		{
+			name:   "Safe Method Without CSRF Token",
+			method: http.MethodGet,
+			setupMock: func(m *mocks.MockSessionService) {
+				m.EXPECT().Authenticate(gomock.Any(), "session-token").Return(session, nil).Times(1)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   userID.String(),
+		},
+		{
+			name:      "Unsafe Method With CSRF Token",
+			method:    http.MethodPost,
+			csrfToken: "csrf-token",
+			setupMock: func(m *mocks.MockSessionService) {
+				m.EXPECT().Authenticate(gomock.Any(), "session-token").Return(session, nil).Times(1)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   userID.String(),
+		},
+		{
+			name:   "Unsafe Method Without CSRF Token",
+			method: http.MethodPost,
+			setupMock: func(m *mocks.MockSessionService) {
+				m.EXPECT().Authenticate(gomock.Any(), "session-token").Return(session, nil).Times(1)
+			},
+			expectedStatus: fiber.StatusForbidden,
+			expectedBody:   `"message":"invalid csrf token"`,
+		},
+		{
+			name:   "Expired Session",
+			method: http.MethodGet,
+			setupMock: func(m *mocks.MockSessionService) {
+				m.EXPECT().Authenticate(gomock.Any(), "session-token").Return(nil, port.ErrSessionExpired).Times(1)
+			},
+			expectedStatus: fiber.StatusUnauthorized,
+			expectedBody:   `"message":"session expired"`,
+			clearsCookies:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockSessionService := mocks.NewMockSessionService(ctrl)
+			tc.setupMock(mockSessionService)
+
+			handler := NewHandler(&core.Application{SessionService: mockSessionService})
+
+			router := fiber.New(fiber.Config{
+				ErrorHandler:    ErrorHandler,
+				StructValidator: StructValidator(),
+			})
+			router.Add([]string{tc.method}, "/", handler.Authenticate, func(ctx fiber.Ctx) error {
+				principal, err := principalFromCtx(ctx)
+				if err != nil {
+					return err
+				}
+
+				return ctx.SendString(principal.UserID.String())
+			})
+
+			req := httptest.NewRequest(tc.method, "/", nil)
+			req.AddCookie(&http.Cookie{Name: "session", Value: "session-token"})
+			if tc.csrfToken != "" {
+				req.Header.Set("X-CSRF-Token", tc.csrfToken)
+			}
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
+			assert.Equal(t, tc.clearsCookies, len(resp.Header.Values("Set-Cookie")) > 0)
+		})
+	}
+}
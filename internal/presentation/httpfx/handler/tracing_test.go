@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"app/pkg/httpserver"
+	"app/pkg/tracing"
+
+	fiberzerolog "github.com/gofiber/contrib/v3/zerolog"
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	errDatabase := errors.New("database unavailable")
+
+	testCases := []struct {
+		name           string
+		path           string
+		traceparent    string
+		expectedName   string
+		expectedStatus int
+		expectedCode   codes.Code
+		expectedEvents int
+	}{
+		{
+			name:           "Route Template",
+			path:           "/users/42",
+			expectedName:   "GET /users/:id",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Continued Trace",
+			path:           "/users/42",
+			traceparent:    traceparent,
+			expectedName:   "GET /users/:id",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Client Error",
+			path:           "/users/0",
+			expectedName:   "GET /users/:id",
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "Internal Error",
+			path:           "/users/500",
+			expectedName:   "GET /users/:id",
+			expectedStatus: fiber.StatusInternalServerError,
+			expectedCode:   codes.Error,
+			expectedEvents: 1,
+		},
+		{
+			name:           "Unmatched Route",
+			path:           "/unknown",
+			expectedName:   "GET",
+			expectedStatus: fiber.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			logger := zerolog.New(&buf)
+			accessLogger := zerolog.Nop()
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+			router := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			router.Use(httpserver.Tracing(provider, tracing.NewPropagator()))
+			// the access log answers the errors, as in the server
+			router.Use(fiberzerolog.New(fiberzerolog.Config{Logger: &accessLogger}))
+			router.Use(RequestLogger(&logger))
+			router.Get("/users/:id", func(ctx fiber.Ctx) error {
+				zerolog.Ctx(ctx.Context()).Info().Msg("service log")
+
+				switch ctx.Params("id") {
+				case "0":
+					return fiber.ErrNotFound
+				case "500":
+					return errDatabase
+				default:
+					return ctx.SendStatus(fiber.StatusOK)
+				}
+			})
+
+			req := httptest.NewRequest("GET", tc.path, nil)
+			if tc.traceparent != "" {
+				req.Header.Set("traceparent", tc.traceparent)
+			}
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+
+			span := spans[0]
+			assert.Equal(t, tc.expectedName, span.Name)
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+			assert.Equal(t, tc.expectedCode, span.Status.Code)
+			assert.Len(t, span.Events, tc.expectedEvents)
+			assert.Contains(t, span.Attributes, attribute.Int("http.response.status_code", tc.expectedStatus))
+
+			traceID, spanID := span.SpanContext.TraceID().String(), span.SpanContext.SpanID().String()
+			assert.Equal(t, "00-"+traceID+"-"+spanID+"-01", resp.Header.Get("traceparent"))
+
+			if tc.traceparent != "" {
+				assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+				assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+			}
+
+			if tc.expectedName == "GET" {
+				return
+			}
+
+			var fields map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+			assert.Equal(t, traceID, fields["trace_id"])
+			assert.Equal(t, spanID, fields["span_id"])
+		})
+	}
+}
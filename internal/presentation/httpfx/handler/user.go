@@ -12,7 +12,8 @@ import (
 )
 
 type createUserRequest struct {
-	Username string `json:"username"`
+	Username string `json:"username" validate:"required,min=3,max=32"`
+	Password string `json:"password" validate:"min=8,max=128"`
 }
 
 type userResponse struct {
@@ -39,15 +40,17 @@ func newUserResponse(user *entity.User) userResponse {
 //	@Param			payload	body		createUserRequest	true	"CreateUser user payload"
 //	@Success		200		{object}	userResponse
 //	@Failure		400		{object}	map[string]string
+//	@Failure		422		{object}	ValidationError
 //	@Router			/users [post]
 func (h *Handler) CreateUser(ctx fiber.Ctx) error {
 	req := new(createUserRequest)
 	if err := ctx.Bind().JSON(req); err != nil {
-		return newBindError(err)
+		return newBindError(err, req)
 	}
 
 	user, err := h.app.UserService.Create(ctx.Context(), dto.CreateUser{
 		Username: req.Username,
+		Password: req.Password,
 	})
 	if err != nil {
 		return fmt.Errorf("create user: %w", err)
@@ -57,7 +60,7 @@ func (h *Handler) CreateUser(ctx fiber.Ctx) error {
 }
 
 type getUserByIDRequest struct {
-	ID types.ID `uri:"id"`
+	ID string `uri:"id" validate:"required,uuid"`
 }
 
 // GetUserByID
@@ -67,21 +70,89 @@ type getUserByIDRequest struct {
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
 //	@Param			id	path		string	true	"User ID"
 //	@Success		200	{object}	userResponse
 //	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	ClientError
+//	@Failure		403	{object}	ClientError
 //	@Failure		404	{object}	map[string]string
 //	@Router			/users/{id} [get]
 func (h *Handler) GetUserByID(ctx fiber.Ctx) error {
 	req := new(getUserByIDRequest)
 	if err := ctx.Bind().All(req); err != nil {
-		return newBindError(err)
+		return newBindError(err, req)
 	}
 
-	user, err := h.app.UserService.GetByID(ctx.Context(), req.ID)
+	user, err := h.app.UserService.GetByID(ctx.Context(), parseID(req.ID))
 	if err != nil {
 		return fmt.Errorf("get user by id: %w", err)
 	}
 
 	return ctx.JSON(newUserResponse(user))
 }
+
+// userFilterRequest holds the filters shared by the user listing endpoints.
+type userFilterRequest struct {
+	Username      string     `query:"username" validate:"max=32"`
+	CreatedAfter  *time.Time `query:"created_after"`
+	CreatedBefore *time.Time `query:"created_before"`
+}
+
+func (r userFilterRequest) toDTO() dto.UserFilter {
+	return dto.UserFilter{
+		Username:      r.Username,
+		CreatedAfter:  r.CreatedAfter,
+		CreatedBefore: r.CreatedBefore,
+	}
+}
+
+type listUsersRequest struct {
+	userFilterRequest
+
+	Limit  uint64 `query:"limit" validate:"max=100"`
+	Offset uint64 `query:"offset"`
+}
+
+// ListUsers
+//
+//	@Summary		List users
+//	@Description	List users ordered by creation, optionally filtered.
+//	@Tags			users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			username		query		string	false	"Username contains (case-insensitive)"
+//	@Param			created_after	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_before	query		string	false	"Created before (RFC 3339)"
+//	@Param			limit			query		int		false	"Page size (max 100)"
+//	@Param			offset			query		int		false	"Page offset"
+//	@Success		200				{array}		userResponse
+//	@Failure		400				{object}	ClientError
+//	@Failure		401				{object}	ClientError
+//	@Failure		403				{object}	ClientError
+//	@Failure		422				{object}	ValidationError
+//	@Router			/users [get]
+func (h *Handler) ListUsers(ctx fiber.Ctx) error {
+	req := new(listUsersRequest)
+	if err := ctx.Bind().Query(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	users, err := h.app.UserService.List(ctx.Context(), dto.ListUsers{
+		Filter: req.toDTO(),
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		return fmt.Errorf("list users: %w", err)
+	}
+
+	resp := make([]userResponse, 0, len(users))
+	for _, user := range users {
+		resp = append(resp, newUserResponse(user))
+	}
+
+	return ctx.JSON(resp)
+}
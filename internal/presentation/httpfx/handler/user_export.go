@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/parquet-go/parquet-go"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	exportFormatCSV     = "csv"
+	exportFormatNDJSON  = "ndjson"
+	exportFormatParquet = "parquet"
+
+	// exportFlushEvery bounds how many encoded rows are buffered before being sent to the client.
+	exportFlushEvery = 500
+
+	exportTracerName = "app/internal/presentation/httpfx/handler"
+)
+
+var exportContentTypes = map[string]string{
+	exportFormatCSV:     "text/csv; charset=utf-8",
+	exportFormatNDJSON:  "application/x-ndjson",
+	exportFormatParquet: "application/vnd.apache.parquet",
+}
+
+type userEncoder interface {
+	Encode(user *entity.User) error
+	Close() error
+}
+
+type csvUserEncoder struct {
+	writer *csv.Writer
+}
+
+func newCSVUserEncoder(w io.Writer) (*csvUserEncoder, error) {
+	enc := &csvUserEncoder{writer: csv.NewWriter(w)}
+
+	if err := enc.writer.Write([]string{"id", "username", "created_at"}); err != nil {
+		return nil, fmt.Errorf("write header: %w", err)
+	}
+
+	return enc, nil
+}
+
+func (e *csvUserEncoder) Encode(user *entity.User) error {
+	return e.writer.Write([]string{user.ID.String(), user.Username, user.CreatedAt.Format(time.RFC3339Nano)})
+}
+
+func (e *csvUserEncoder) Close() error {
+	e.writer.Flush()
+
+	return e.writer.Error()
+}
+
+type ndjsonUserEncoder struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONUserEncoder(w io.Writer) *ndjsonUserEncoder {
+	return &ndjsonUserEncoder{encoder: json.NewEncoder(w)}
+}
+
+func (e *ndjsonUserEncoder) Encode(user *entity.User) error {
+	return e.encoder.Encode(newUserResponse(user))
+}
+
+func (e *ndjsonUserEncoder) Close() error {
+	return nil
+}
+
+type userParquetRecord struct {
+	ID        [16]byte  `parquet:"id,uuid"`
+	Username  string    `parquet:"username"`
+	CreatedAt time.Time `parquet:"created_at,timestamp(microsecond)"`
+}
+
+// parquetUserEncoder keeps at most one row group in memory.
+type parquetUserEncoder struct {
+	writer *parquet.GenericWriter[userParquetRecord]
+	buffer []userParquetRecord
+}
+
+func newParquetUserEncoder(w io.Writer) *parquetUserEncoder {
+	return &parquetUserEncoder{
+		writer: parquet.NewGenericWriter[userParquetRecord](w, parquet.MaxRowsPerRowGroup(exportFlushEvery)),
+		buffer: make([]userParquetRecord, 0, exportFlushEvery),
+	}
+}
+
+func (e *parquetUserEncoder) Encode(user *entity.User) error {
+	e.buffer = append(e.buffer, userParquetRecord{
+		ID:        user.ID,
+		Username:  user.Username,
+		CreatedAt: user.CreatedAt,
+	})
+
+	if len(e.buffer) < exportFlushEvery {
+		return nil
+	}
+
+	return e.flush()
+}
+
+func (e *parquetUserEncoder) flush() error {
+	if len(e.buffer) == 0 {
+		return nil
+	}
+
+	if _, err := e.writer.Write(e.buffer); err != nil {
+		return fmt.Errorf("write rows: %w", err)
+	}
+
+	e.buffer = e.buffer[:0]
+
+	return e.writer.Flush()
+}
+
+func (e *parquetUserEncoder) Close() error {
+	if err := e.flush(); err != nil {
+		return err
+	}
+
+	return e.writer.Close()
+}
+
+func newUserEncoder(format string, w io.Writer) (userEncoder, error) {
+	switch format {
+	case exportFormatCSV:
+		return newCSVUserEncoder(w)
+	case exportFormatNDJSON:
+		return newNDJSONUserEncoder(w), nil
+	case exportFormatParquet:
+		return newParquetUserEncoder(w), nil
+	default:
+		return nil, fmt.Errorf("unknown export format: %s", format)
+	}
+}
+
+type exportUsersRequest struct {
+	userFilterRequest
+
+	Format string `query:"format"`
+}
+
+// ExportUsers
+//
+//	@Summary		Export users
+//	@Description	Stream users matching the filters as a CSV, NDJSON or Parquet file.
+//	@Description	The status is sent before the rows, a failure while streaming truncates the file of the 200 response.
+//	@Tags			users
+//	@Produce		text/csv,application/x-ndjson,application/vnd.apache.parquet
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			format			query		string	false	"Export format"	Enums(csv, ndjson, parquet)	default(csv)
+//	@Param			username		query		string	false	"Username contains (case-insensitive)"
+//	@Param			created_after	query		string	false	"Created at or after (RFC 3339)"
+//	@Param			created_before	query		string	false	"Created before (RFC 3339)"
+//	@Success		200				{file}		file
+//	@Failure		400				{object}	ClientError
+//	@Failure		401				{object}	ClientError
+//	@Failure		403				{object}	ClientError
+//	@Failure		422				{object}	ValidationError
+//	@Router			/users/export [get]
+func (h *Handler) ExportUsers(ctx fiber.Ctx) error {
+	req := &exportUsersRequest{Format: exportFormatCSV}
+	if err := ctx.Bind().Query(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	contentType, ok := exportContentTypes[req.Format]
+	if !ok {
+		return newValidationError("format", "unsupported export format")
+	}
+
+	ctx.Attachment(fmt.Sprintf("users-%s.%s", time.Now().UTC().Format("20060102T150405Z"), req.Format))
+	ctx.Set(fiber.HeaderContentType, contentType)
+
+	// The stream writer runs after the handler returns, so nothing may be read from ctx inside it.
+	reqCtx := ctx.Context()
+	filter := req.toDTO()
+
+	return ctx.SendStreamWriter(func(w *bufio.Writer) {
+		// The server span, the metrics and the access log end with the handler, before the rows are
+		// streamed, so the export is traced by a span of its own.
+		spanCtx, span := trace.SpanFromContext(reqCtx).TracerProvider().Tracer(exportTracerName).
+			Start(reqCtx, "export users", trace.WithAttributes(attribute.String("export.format", req.Format)))
+		defer span.End()
+
+		rows, err := h.exportUsers(spanCtx, req.Format, filter, w)
+		span.SetAttributes(attribute.Int("export.rows", rows))
+
+		if err != nil {
+			// The status line is already sent, the client sees a truncated body.
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			zerolog.Ctx(reqCtx).Error().Err(err).Str("format", req.Format).Int("rows", rows).Msg("export users")
+		}
+	})
+}
+
+// exportUsers returns the number of rows encoded, also on failure.
+func (h *Handler) exportUsers(
+	ctx context.Context, format string, filter dto.UserFilter, w *bufio.Writer,
+) (int, error) {
+	encoder, err := newUserEncoder(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := 0
+
+	err = h.app.UserService.Export(ctx, filter, func(user *entity.User) error {
+		if err := encoder.Encode(user); err != nil {
+			return fmt.Errorf("encode user: %w", err)
+		}
+
+		rows++
+		if rows%exportFlushEvery == 0 {
+			return w.Flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return rows, fmt.Errorf("export users: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return rows, fmt.Errorf("close encoder: %w", err)
+	}
+
+	return rows, w.Flush()
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"app/internal/core"
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	"app/internal/mocks"
+	"app/pkg/httpserver"
+	"app/pkg/tracing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserHandler_Export(t *testing.T) {
+	createdAt := time.Date(2025, 11, 5, 7, 45, 29, 0, time.UTC)
+	users := []*entity.User{
+		{ID: uuid.Must(uuid.NewV7()), Username: "alice", CreatedAt: createdAt},
+		{ID: uuid.Must(uuid.NewV7()), Username: "bob", CreatedAt: createdAt},
+	}
+
+	testCases := []struct {
+		name                string
+		query               string
+		expectedFilter      dto.UserFilter
+		expectedStatus      int
+		expectedContentType string
+		assertBody          func(t *testing.T, body []byte)
+	}{
+		{
+			name:                "CSV",
+			query:               "?username=al",
+			expectedFilter:      dto.UserFilter{Username: "al"},
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			assertBody: func(t *testing.T, body []byte) {
+				records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 3)
+				assert.Equal(t, []string{"id", "username", "created_at"}, records[0])
+				assert.Equal(t, []string{users[1].ID.String(), "bob", "2025-11-05T07:45:29Z"}, records[2])
+			},
+		},
+		{
+			name:                "NDJSON",
+			query:               "?format=ndjson&created_after=2025-01-01T00:00:00Z",
+			expectedFilter:      dto.UserFilter{CreatedAfter: new(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))},
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			assertBody: func(t *testing.T, body []byte) {
+				lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+				require.Len(t, lines, 2)
+				assert.Contains(t, lines[0], `"username":"alice"`)
+			},
+		},
+		{
+			name:                "Parquet",
+			query:               "?format=parquet",
+			expectedStatus:      fiber.StatusOK,
+			expectedContentType: "application/vnd.apache.parquet",
+			assertBody: func(t *testing.T, body []byte) {
+				records, err := parquet.Read[userParquetRecord](bytes.NewReader(body), int64(len(body)))
+				require.NoError(t, err)
+				require.Len(t, records, 2)
+				assert.Equal(t, [16]byte(users[0].ID), records[0].ID)
+				assert.Equal(t, "alice", records[0].Username)
+				assert.True(t, createdAt.Equal(records[0].CreatedAt))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserService := mocks.NewMockUserService(ctrl)
+			mockUserService.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, filter dto.UserFilter, fn func(*entity.User) error) error {
+					assert.Equal(t, tc.expectedFilter, filter)
+
+					for _, user := range users {
+						if err := fn(user); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+			).Times(1)
+
+			handler := NewHandler(&core.Application{UserService: mockUserService})
+
+			router := fiber.New(fiber.Config{
+				ErrorHandler: ErrorHandler,
+			})
+			router.Get("/users/export", handler.ExportUsers)
+
+			resp, err := router.Test(httptest.NewRequest("GET", "/users/export"+tc.query, nil))
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedContentType, resp.Header.Get(fiber.HeaderContentType))
+			assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "attachment; filename=\"users-")
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			tc.assertBody(t, body)
+		})
+	}
+}
+
+func TestUserHandler_Export_UnsupportedFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewHandler(&core.Application{UserService: mocks.NewMockUserService(ctrl)})
+
+	router := fiber.New(fiber.Config{
+		ErrorHandler: ErrorHandler,
+	})
+	router.Get("/users/export", handler.ExportUsers)
+
+	resp, err := router.Test(httptest.NewRequest("GET", "/users/export?format=xml", nil))
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, fiber.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestUserHandler_Export_StreamError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errStream := errors.New("connection reset")
+
+	mockUserService := mocks.NewMockUserService(ctrl)
+	mockUserService.EXPECT().Export(gomock.Any(), dto.UserFilter{}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ dto.UserFilter, fn func(*entity.User) error) error {
+			if err := fn(&entity.User{ID: uuid.Must(uuid.NewV7()), Username: "alice"}); err != nil {
+				return err
+			}
+
+			return errStream
+		},
+	).Times(1)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	handler := NewHandler(&core.Application{UserService: mockUserService})
+
+	router := fiber.New(fiber.Config{
+		ErrorHandler: ErrorHandler,
+	})
+	router.Use(httpserver.Tracing(provider, tracing.NewPropagator()))
+	router.Get("/users/export", handler.ExportUsers)
+
+	resp, err := router.Test(httptest.NewRequest("GET", "/users/export", nil))
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	// the rows sent before the failure make a truncated 200 response
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "alice")
+
+	// the export span outlives the server span, its parent
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	server, export := spans[0], spans[1]
+	assert.Equal(t, "GET /users/export", server.Name)
+	assert.Equal(t, "export users", export.Name)
+	assert.Equal(t, server.SpanContext.SpanID(), export.Parent.SpanID())
+	assert.Equal(t, codes.Error, export.Status.Code)
+	assert.Len(t, export.Events, 1)
+	assert.Contains(t, export.Attributes, attribute.Int("export.rows", 1))
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"app/internal/core/dto"
+	domainErrors "app/internal/core/error"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const importUsersFileField = "file"
+
+var importFormatsByMediaType = map[string]dto.ImportFormat{
+	"text/csv":             dto.ImportFormatCSV,
+	"application/csv":      dto.ImportFormatCSV,
+	"application/x-ndjson": dto.ImportFormatNDJSON,
+	"application/ndjson":   dto.ImportFormatNDJSON,
+	"application/jsonl":    dto.ImportFormatNDJSON,
+}
+
+type importUsersRequest struct {
+	Format string `query:"format"`
+}
+
+type importUsersRowError struct {
+	Line     int    `json:"line"`
+	Username string `json:"username"`
+	ClientError
+}
+
+type importUsersResponse struct {
+	Total      int                   `json:"total"`
+	Imported   int                   `json:"imported"`
+	Rejected   []importUsersRowError `json:"rejected"`
+	Duplicates []importUsersRowError `json:"duplicates"`
+}
+
+func newImportUsersResponse(ctx fiber.Ctx, report *dto.ImportUsersReport) importUsersResponse {
+	return importUsersResponse{
+		Total:      report.Total,
+		Imported:   report.Imported,
+		Rejected:   newImportUsersRowErrors(ctx, report.Rejected),
+		Duplicates: newImportUsersRowErrors(ctx, report.Duplicates),
+	}
+}
+
+func newImportUsersRowErrors(ctx fiber.Ctx, rows []dto.ImportUsersRowError) []importUsersRowError {
+	resp := make([]importUsersRowError, 0, len(rows))
+
+	for _, row := range rows {
+		rowErr := importUsersRowError{
+			Line:     row.Line,
+			Username: row.Username,
+		}
+
+		clientErr := newBadRequest(row.Err.Error())
+		if domainErr, ok := errors.AsType[*domainErrors.DomainError](row.Err); ok {
+			clientErr = newDomainClientError(domainErr)
+		}
+
+		clientErr, _ = localizeError(ctx, clientErr, nil)
+		rowErr.ClientError = *clientErr
+
+		resp = append(resp, rowErr)
+	}
+
+	return resp
+}
+
+// ImportUsers
+//
+//	@Summary		Import users
+//	@Description	Bulk import users from a CSV (with a username header) or NDJSON upload.
+//	@Description	The upload is sent either as the raw request body or as the "file" field of a multipart form.
+//	@Description	The format is taken from the format query parameter, the content type or the file extension.
+//	@Description	Uploads larger than USER_IMPORT_MAX_SIZE or with more rows than USER_IMPORT_MAX_ROWS are rejected.
+//	@Tags			users
+//	@Accept			text/csv,application/x-ndjson,multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			format	query		string	false	"Upload format"	Enums(csv, ndjson)
+//	@Param			file	formData	file	false	"Upload file"
+//	@Success		200		{object}	importUsersResponse
+//	@Failure		400		{object}	ClientError
+//	@Failure		401		{object}	ClientError
+//	@Failure		403		{object}	ClientError
+//	@Failure		422		{object}	ValidationError
+//	@Router			/users/import [post]
+func (h *Handler) ImportUsers(ctx fiber.Ctx) error {
+	req := new(importUsersRequest)
+	if err := ctx.Bind().Query(req); err != nil {
+		return newBindError(err, req)
+	}
+
+	format := dto.ImportFormat(strings.ToLower(req.Format))
+	if format == "" {
+		format = importFormatsByMediaType[ctx.MediaType()]
+	}
+
+	var source io.Reader
+
+	if ctx.MediaType() == fiber.MIMEMultipartForm {
+		fileHeader, err := ctx.FormFile(importUsersFileField)
+		if err != nil {
+			return newValidationError(importUsersFileField, err.Error())
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return fmt.Errorf("open upload: %w", err)
+		}
+
+		defer func() { _ = file.Close() }()
+
+		if format == "" {
+			format = dto.ImportFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), "."))
+		}
+
+		source = file
+	} else {
+		source = bytes.NewReader(ctx.Body())
+	}
+
+	report, err := h.app.UserService.Import(ctx.Context(), dto.ImportUsers{
+		Format: format,
+		Source: source,
+	})
+	if err != nil {
+		return fmt.Errorf("import users: %w", err)
+	}
+
+	return ctx.JSON(newImportUsersResponse(ctx, report))
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"app/internal/core"
+	"app/internal/core/dto"
+	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/core/port"
+	"app/internal/mocks"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserHandler_Import(t *testing.T) {
+	report := &dto.ImportUsersReport{
+		Total:    3,
+		Imported: 1,
+		Rejected: []dto.ImportUsersRowError{
+			{Line: 3, Username: "ab", Err: entity.ErrUsernameTooShort},
+		},
+		Duplicates: []dto.ImportUsersRowError{
+			{Line: 4, Username: "bob", Err: port.ErrUserAlreadyExists},
+		},
+	}
+
+	multipartBody := new(bytes.Buffer)
+	writer := multipart.NewWriter(multipartBody)
+	part, err := writer.CreateFormFile("file", "users.ndjson")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(`{"username":"alice"}`))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	testCases := []struct {
+		name           string
+		url            string
+		contentType    string
+		body           []byte
+		serviceErr     error
+		expectedFormat dto.ImportFormat
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{
+			name:           "CSV Body",
+			url:            "/users/import",
+			contentType:    "text/csv",
+			body:           []byte("username\nalice\n"),
+			expectedFormat: dto.ImportFormatCSV,
+			expectedStatus: fiber.StatusOK,
+			expectedBody: []string{
+				`"total":3`,
+				`"imported":1`,
+				`{"line":3,"username":"ab","code":422,"error_code":"user.username_too_short","message":"username is too short"}`,
+				`{"line":4,"username":"bob","code":409,"error_code":"user.already_exists","message":"user already exists"}`,
+			},
+		},
+		{
+			name:           "Format From Query",
+			url:            "/users/import?format=NDJSON",
+			contentType:    "application/octet-stream",
+			body:           []byte(`{"username":"alice"}`),
+			expectedFormat: dto.ImportFormatNDJSON,
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   []string{`"imported":1`},
+		},
+		{
+			name:           "Multipart File",
+			url:            "/users/import",
+			contentType:    writer.FormDataContentType(),
+			body:           multipartBody.Bytes(),
+			expectedFormat: dto.ImportFormatNDJSON,
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   []string{`"imported":1`},
+		},
+		{
+			name:           "Too Large",
+			url:            "/users/import",
+			contentType:    "text/csv",
+			body:           []byte("username\nalice\n"),
+			serviceErr:     port.ErrImportTooLarge.With(domainErrors.Arg("max_size", 10)),
+			expectedFormat: dto.ImportFormatCSV,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody: []string{
+				`"field":"file"`,
+				`"code":"user.import.too_large","message":"import is too large","data":{"max_size":10}`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserService := mocks.NewMockUserService(ctrl)
+			mockUserService.EXPECT().Import(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ any, input dto.ImportUsers) (*dto.ImportUsersReport, error) {
+					assert.Equal(t, tc.expectedFormat, input.Format)
+
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+
+					return report, nil
+				},
+			).Times(1)
+
+			handler := NewHandler(&core.Application{UserService: mockUserService})
+
+			router := fiber.New(fiber.Config{
+				ErrorHandler: ErrorHandler,
+			})
+			router.Post("/users/import", handler.ImportUsers)
+
+			req := httptest.NewRequest("POST", tc.url, bytes.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			for _, expected := range tc.expectedBody {
+				assert.Contains(t, string(bodyBytes), expected)
+			}
+		})
+	}
+}
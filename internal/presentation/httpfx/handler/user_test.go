@@ -6,11 +6,14 @@ import (
 	"errors"
 	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"app/internal/core"
 	"app/internal/core/dto"
 	"app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	"app/internal/core/port"
 	"app/internal/mocks"
 
 	"github.com/gofiber/fiber/v3"
@@ -35,12 +38,11 @@ func TestUserHandler_Create(t *testing.T) {
 	successBody, _ := json.Marshal(newUserResponse(mockUser))
 
 	testCases := []struct {
-		name            string
-		body            []byte
-		setupMock       func(m *mocks.MockUserService)
-		expectedStatus  int
-		expectedBody    string
-		bodyMustBeEmpty bool
+		name           string
+		body           []byte
+		setupMock      func(m *mocks.MockUserService)
+		expectedStatus int
+		expectedBody   string
 	}{
 		{
 			name: "Success",
@@ -53,11 +55,38 @@ func TestUserHandler_Create(t *testing.T) {
 		},
 		{
 			name: "Binding Error - Invalid JSON",
-			body: []byte(`{"username": 123}`),
+			body: []byte(`{"username": `),
 			setupMock: func(m *mocks.MockUserService) {
 			},
 			expectedStatus: fiber.StatusBadRequest,
-			expectedBody:   `cannot unmarshal number into Go struct field`,
+			expectedBody:   `unexpected end of JSON input`,
+		},
+		{
+			name: "Binding Error - Invalid Type",
+			body: []byte(`{"username": 123}`),
+			setupMock: func(m *mocks.MockUserService) {
+			},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody:   `{"field":"username","code":"validation.type","message":"username must be a string"}`,
+		},
+		{
+			name:           "Rule Errors",
+			body:           []byte(`{"username": "no", "password": "short"}`),
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody: `"errors":[` +
+				`{"field":"username","code":"validation.min","message":"username must be at least 3 characters","data":{"min":"3"}},` +
+				`{"field":"password","code":"validation.min","message":"password must be at least 8 characters","data":{"min":"8"}}]`,
+		},
+		{
+			name: "Validation Error",
+			body: []byte(`{"username": "testuser"}`),
+			setupMock: func(m *mocks.MockUserService) {
+				m.EXPECT().Create(gomock.Any(), dtoInput).
+					Return(nil, entity.ErrUsernameTooLong.With(domainErrors.Arg("max", 32))).
+					Times(1)
+			},
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody:   `"field":"username"`,
 		},
 		{
 			name: "Service Error",
@@ -65,8 +94,8 @@ func TestUserHandler_Create(t *testing.T) {
 			setupMock: func(m *mocks.MockUserService) {
 				m.EXPECT().Create(gomock.Any(), dtoInput).Return(nil, serviceErr).Times(1)
 			},
-			expectedStatus:  fiber.StatusInternalServerError,
-			bodyMustBeEmpty: true,
+			expectedStatus: fiber.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500}`,
 		},
 	}
 
@@ -80,12 +109,13 @@ func TestUserHandler_Create(t *testing.T) {
 				tc.setupMock(mockUserService)
 			}
 
-			app := core.NewApplication(mockUserService)
+			app := &core.Application{UserService: mockUserService}
 
 			handler := NewHandler(app)
 
 			router := fiber.New(fiber.Config{
-				ErrorHandler: ErrorHandler,
+				ErrorHandler:    ErrorHandler,
+				StructValidator: StructValidator(),
 			})
 			router.Post("/users", handler.CreateUser)
 
@@ -102,13 +132,7 @@ func TestUserHandler_Create(t *testing.T) {
 			bodyBytes, err := io.ReadAll(resp.Body)
 			require.NoError(t, err)
 
-			if tc.bodyMustBeEmpty {
-				assert.Empty(t, bodyBytes)
-
-				return
-			} else {
-				assert.Contains(t, string(bodyBytes), tc.expectedBody)
-			}
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
 		})
 	}
 }
@@ -124,12 +148,11 @@ func TestUserHandler_GetByID(t *testing.T) {
 	successBody, _ := json.Marshal(newUserResponse(mockUser))
 
 	testCases := []struct {
-		name            string
-		userIDString    string
-		setupMock       func(m *mocks.MockUserService)
-		expectedStatus  int
-		expectedBody    string
-		bodyMustBeEmpty bool
+		name           string
+		userIDString   string
+		setupMock      func(m *mocks.MockUserService)
+		expectedStatus int
+		expectedBody   string
 	}{
 		{
 			name:         "Success",
@@ -146,7 +169,16 @@ func TestUserHandler_GetByID(t *testing.T) {
 			setupMock: func(m *mocks.MockUserService) {
 			},
 			expectedStatus: fiber.StatusUnprocessableEntity,
-			expectedBody:   "invalid UUID",
+			expectedBody:   `{"field":"id","code":"validation.uuid","message":"id must be a valid UUID"}`,
+		},
+		{
+			name:         "User Not Found",
+			userIDString: mockUserID.String(),
+			setupMock: func(m *mocks.MockUserService) {
+				m.EXPECT().GetByID(gomock.Any(), mockUserID).Return(nil, port.ErrUserNotFound).Times(1)
+			},
+			expectedStatus: fiber.StatusNotFound,
+			expectedBody:   `"error_code":"user.not_found"`,
 		},
 		{
 			name:         "Service Error - Not Found",
@@ -154,8 +186,8 @@ func TestUserHandler_GetByID(t *testing.T) {
 			setupMock: func(m *mocks.MockUserService) {
 				m.EXPECT().GetByID(gomock.Any(), mockUserID).Return(nil, errSomething).Times(1)
 			},
-			expectedStatus:  fiber.StatusInternalServerError,
-			bodyMustBeEmpty: true,
+			expectedStatus: fiber.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500}`,
 		},
 	}
 
@@ -169,12 +201,13 @@ func TestUserHandler_GetByID(t *testing.T) {
 				tc.setupMock(mockUserService)
 			}
 
-			app := core.NewApplication(mockUserService)
+			app := &core.Application{UserService: mockUserService}
 
 			handler := NewHandler(app)
 
 			router := fiber.New(fiber.Config{
-				ErrorHandler: ErrorHandler,
+				ErrorHandler:    ErrorHandler,
+				StructValidator: StructValidator(),
 			})
 			router.Get("/users/:id", handler.GetUserByID)
 
@@ -190,13 +223,81 @@ func TestUserHandler_GetByID(t *testing.T) {
 			bodyBytes, err := io.ReadAll(resp.Body)
 			require.NoError(t, err)
 
-			if tc.bodyMustBeEmpty {
-				assert.Empty(t, bodyBytes)
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
+		})
+	}
+}
+
+func TestUserHandler_List(t *testing.T) {
+	mockUser := &entity.User{ID: uuid.Must(uuid.NewV7()), Username: "testuser"}
+
+	testCases := []struct {
+		name           string
+		query          string
+		setupMock      func(m *mocks.MockUserService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "Success",
+			query: "username=test&limit=10&offset=5",
+			setupMock: func(m *mocks.MockUserService) {
+				m.EXPECT().List(gomock.Any(), dto.ListUsers{
+					Filter: dto.UserFilter{Username: "test"},
+					Limit:  10,
+					Offset: 5,
+				}).Return([]*entity.User{mockUser}, nil).Times(1)
+			},
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   `"username":"testuser"`,
+		},
+		{
+			name:           "Rule Errors",
+			query:          "username=" + strings.Repeat("a", 33) + "&limit=101",
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody: `"errors":[` +
+				`{"field":"username","code":"validation.max","message":"username must be at most 32 characters","data":{"max":"32"}},` +
+				`{"field":"limit","code":"validation.max","message":"limit must be at most 100","data":{"max":"100"}}]`,
+		},
+		{
+			name:           "Rule And Type Errors",
+			query:          "username=" + strings.Repeat("a", 33) + "&limit=many",
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody: `"errors":[` +
+				`{"field":"limit","code":"validation.type","message":"limit has an invalid value"},` +
+				`{"field":"username","code":"validation.max","message":"username must be at most 32 characters","data":{"max":"32"}}]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-				return
-			} else {
-				assert.Contains(t, string(bodyBytes), tc.expectedBody)
+			mockUserService := mocks.NewMockUserService(ctrl)
+			if tc.setupMock != nil {
+				tc.setupMock(mockUserService)
 			}
+
+			handler := NewHandler(&core.Application{UserService: mockUserService})
+
+			router := fiber.New(fiber.Config{
+				ErrorHandler:    ErrorHandler,
+				StructValidator: StructValidator(),
+			})
+			router.Get("/users", handler.ListUsers)
+
+			resp, err := router.Test(httptest.NewRequest("GET", "/users?"+tc.query, nil))
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
 		})
 	}
 }
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	domainErrors "app/internal/core/error"
+	"app/internal/types"
+	"app/pkg/validator"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/schema"
+	"github.com/google/uuid"
+)
+
+// Codes of the field errors found before the request reaches the services.
+const (
+	validationCodePrefix = "validation."
+	validationCodeType   = validationCodePrefix + "type"
+)
+
+// The codes of the field errors are registered so that the catalog lists them.
+var (
+	_ = domainErrors.Register(validationCodeType, domainErrors.KindInvalid, "value is of the wrong type")
+	_ = domainErrors.Register(validationCodePrefix+validator.RuleRequired, domainErrors.KindInvalid,
+		"value is required")
+	_ = domainErrors.Register(validationCodePrefix+validator.RuleMin, domainErrors.KindInvalid,
+		"value is below the minimum")
+	_ = domainErrors.Register(validationCodePrefix+validator.RuleMax, domainErrors.KindInvalid,
+		"value is above the maximum")
+	_ = domainErrors.Register(validationCodePrefix+validator.RuleRegex, domainErrors.KindInvalid,
+		"value does not match the expected format")
+	_ = domainErrors.Register(validationCodePrefix+validator.RuleUUID, domainErrors.KindInvalid,
+		"value is not a valid UUID")
+)
+
+// requestValidator checks the validate tags of the request structs when they are bound.
+var requestValidator = validator.New()
+
+// StructValidator is the validator the server runs on bound requests.
+func StructValidator() fiber.StructValidator {
+	return requestValidator
+}
+
+// FieldError describes an invalid input field, nested fields are addressed by their JSON path.
+type FieldError struct {
+	Field   string         `json:"field" example:"profile.display_name"`
+	Code    string         `json:"code,omitempty" example:"validation.max"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data,omitempty"`
+
+	// messageKey identifies the message translation when it differs from Code.
+	messageKey string
+}
+
+type ValidationError struct {
+	ClientError
+
+	// Field is the first invalid field, Errors lists all of them.
+	Field  string       `json:"field"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+func (ve *ValidationError) Error() string {
+	return fmt.Sprintf("validation error - field: %s; message: %s", ve.Field, ve.Message)
+}
+
+func newValidationError(field, message string) *ValidationError {
+	return newValidationErrors([]FieldError{{Field: field, Message: message}})
+}
+
+// newValidationErrors reports the fields at once, the message being the one of the first field.
+func newValidationErrors(fieldErrs []FieldError) *ValidationError {
+	return &ValidationError{
+		ClientError: ClientError{
+			StatusCode: http.StatusUnprocessableEntity,
+			Code:       http.StatusUnprocessableEntity,
+			Message:    fieldErrs[0].Message,
+		},
+		Field:  fieldErrs[0].Field,
+		Errors: fieldErrs,
+	}
+}
+
+// newBindError maps the errors of ctx.Bind(). When some fields cannot be bound,
+// the validate tags are still checked on the partially bound request, so the
+// response lists every invalid field.
+func newBindError(err error, req any) error {
+	var (
+		validationErrs validator.Errors
+		multiErr       schema.MultiError
+		typeErr        *json.UnmarshalTypeError
+		fieldErrs      []FieldError
+	)
+
+	switch {
+	case errors.As(err, &validationErrs):
+		return newValidationErrors(newRuleFieldErrors(validationErrs))
+	case errors.As(err, &multiErr):
+		var (
+			conversionErr schema.ConversionError
+			unknownKeyErr schema.UnknownKeyError
+			emptyFieldErr schema.EmptyFieldError
+		)
+
+		for _, key := range slices.Sorted(maps.Keys(multiErr)) {
+			singleErr := multiErr[key]
+
+			switch {
+			case errors.As(singleErr, &conversionErr):
+				// the low-level error is missing for the values of invalid syntax
+				message := conversionErr.Key + " has an invalid value"
+				if conversionErr.Err != nil {
+					message = conversionErr.Err.Error()
+				}
+
+				fieldErrs = append(fieldErrs, FieldError{
+					Field:   conversionErr.Key,
+					Code:    validationCodeType,
+					Message: message,
+				})
+			case errors.As(singleErr, &unknownKeyErr):
+				return newBadRequest(unknownKeyErr.Error())
+			case errors.As(singleErr, &emptyFieldErr):
+				fieldErrs = append(fieldErrs, FieldError{
+					Field:   emptyFieldErr.Key,
+					Code:    validationCodePrefix + validator.RuleRequired,
+					Message: emptyFieldErr.Error(),
+				})
+			}
+		}
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		field, typ := jsonFieldPath(typeErr.Field), jsonType(typeErr.Type)
+		fieldErrs = append(fieldErrs, FieldError{
+			Field:      field,
+			Code:       validationCodeType,
+			Message:    fmt.Sprintf("%s must be %s", field, jsonTypeArticles[typ]),
+			messageKey: validationCodeType + "." + typ,
+		})
+	}
+
+	if len(fieldErrs) == 0 {
+		return newBadRequest(err.Error())
+	}
+
+	if errors.As(requestValidator.Validate(req), &validationErrs) {
+		for _, ruleErr := range newRuleFieldErrors(validationErrs) {
+			reported := slices.ContainsFunc(fieldErrs, func(fieldErr FieldError) bool {
+				return fieldErr.Field == ruleErr.Field
+			})
+			if !reported {
+				fieldErrs = append(fieldErrs, ruleErr)
+			}
+		}
+	}
+
+	return newValidationErrors(fieldErrs)
+}
+
+// parseID converts an id the uuid rule already checked.
+func parseID(value string) types.ID {
+	id, _ := uuid.Parse(value)
+
+	return id
+}
+
+func newRuleFieldErrors(validationErrs validator.Errors) []FieldError {
+	fieldErrs := make([]FieldError, 0, len(validationErrs))
+
+	for _, validationErr := range validationErrs {
+		fieldErr := FieldError{
+			Field:   validationErr.Path,
+			Code:    validationCodePrefix + validationErr.Rule,
+			Message: validationErr.Message,
+		}
+		if validationErr.Unit != "" {
+			fieldErr.messageKey = fieldErr.Code + "." + validationErr.Unit
+		}
+		if validationErr.Param != "" {
+			fieldErr.Data = map[string]any{validationErr.Rule: validationErr.Param}
+		}
+
+		fieldErrs = append(fieldErrs, fieldErr)
+	}
+
+	return fieldErrs
+}
+
+// newDomainFieldErrors maps domain errors caused by input fields.
+func newDomainFieldErrors(domainErrs ...*domainErrors.DomainError) []FieldError {
+	fieldErrs := make([]FieldError, 0, len(domainErrs))
+
+	for _, domainErr := range domainErrs {
+		fieldErrs = append(fieldErrs, FieldError{
+			Field:   domainErr.Field(),
+			Code:    domainErr.Code(),
+			Message: domainErr.Message(),
+			Data:    newDomainClientError(domainErr).Data,
+		})
+	}
+
+	return fieldErrs
+}
+
+// jsonFieldPath turns the dotted path of encoding/json, e.g. addresses.0.city,
+// into the path the validator reports, e.g. addresses[0].city.
+func jsonFieldPath(field string) string {
+	var path strings.Builder
+
+	for i, segment := range strings.Split(field, ".") {
+		switch {
+		case isIndex(segment):
+			path.WriteString("[" + segment + "]")
+		case i > 0:
+			path.WriteString("." + segment)
+		default:
+			path.WriteString(segment)
+		}
+	}
+
+	return path.String()
+}
+
+func isIndex(segment string) bool {
+	_, err := strconv.Atoi(segment)
+
+	return err == nil
+}
+
+// JSON types named in the messages of type errors.
+const (
+	jsonTypeString  = "string"
+	jsonTypeBoolean = "boolean"
+	jsonTypeNumber  = "number"
+	jsonTypeArray   = "array"
+	jsonTypeObject  = "object"
+)
+
+var jsonTypeArticles = map[string]string{
+	jsonTypeString:  "a string",
+	jsonTypeBoolean: "a boolean",
+	jsonTypeNumber:  "a number",
+	jsonTypeArray:   "an array",
+	jsonTypeObject:  "an object",
+}
+
+// jsonType names the JSON type a Go type is decoded from.
+func jsonType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.String:
+		return jsonTypeString
+	case reflect.Bool:
+		return jsonTypeBoolean
+	case reflect.Slice, reflect.Array:
+		return jsonTypeArray
+	case reflect.Map, reflect.Struct:
+		return jsonTypeObject
+	default:
+		return jsonTypeNumber
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	domainErrors "app/internal/core/error"
+	"app/internal/types"
+	"app/pkg/validator"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validationTestAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type validationTestBody struct {
+	Name      string                  `json:"name" validate:"required,max=5"`
+	Addresses []validationTestAddress `json:"addresses"`
+}
+
+type validationTestQuery struct {
+	ID    types.ID `query:"id"`
+	Limit int      `query:"limit" validate:"max=10"`
+	Name  string   `query:"name" validate:"required"`
+}
+
+func TestValidationCodes_Registered(t *testing.T) {
+	t.Parallel()
+
+	codes := []string{validationCodeType}
+	for _, rule := range []string{
+		validator.RuleRequired, validator.RuleMin, validator.RuleMax, validator.RuleRegex, validator.RuleUUID,
+	} {
+		codes = append(codes, validationCodePrefix+rule)
+	}
+
+	for _, code := range codes {
+		def, ok := domainErrors.Lookup(code)
+		if assert.True(t, ok, "code %s", code) {
+			assert.Equal(t, fiber.StatusUnprocessableEntity, def.HTTPStatus, "status of %s", code)
+		}
+	}
+}
+
+func TestNewBindError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Nested Body Rules",
+			method:         "POST",
+			target:         "/",
+			body:           `{"name":"too long","addresses":[{"city":"Kyiv"},{}]}`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody: `"errors":[` +
+				`{"field":"name","code":"validation.max","message":"name must be at most 5 characters","data":{"max":"5"}},` +
+				`{"field":"addresses[1].city","code":"validation.required","message":"addresses[1].city is required"}]`,
+		},
+		{
+			name:           "Nested Body Type",
+			method:         "POST",
+			target:         "/",
+			body:           `{"name":"john","addresses":[{"city":1}]}`,
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody: `"errors":[` +
+				`{"field":"addresses[0].city","code":"validation.type","message":"addresses[0].city must be a string"}]`,
+		},
+		{
+			name:           "Query Binding And Rules",
+			method:         "GET",
+			target:         "/?id=123&limit=11",
+			expectedStatus: fiber.StatusUnprocessableEntity,
+			expectedBody: `"errors":[` +
+				`{"field":"id","code":"validation.type","message":"invalid UUID length: 3"},` +
+				`{"field":"limit","code":"validation.max","message":"limit must be at most 10","data":{"max":"10"}},` +
+				`{"field":"name","code":"validation.required","message":"name is required"}]`,
+		},
+		{
+			name:           "Valid",
+			method:         "GET",
+			target:         "/?limit=10&name=john",
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := fiber.New(fiber.Config{
+				ErrorHandler:    ErrorHandler,
+				StructValidator: StructValidator(),
+			})
+			router.Post("/", func(ctx fiber.Ctx) error {
+				req := new(validationTestBody)
+				if err := ctx.Bind().JSON(req); err != nil {
+					return newBindError(err, req)
+				}
+
+				return ctx.SendStatus(fiber.StatusOK)
+			})
+			router.Get("/", func(ctx fiber.Ctx) error {
+				req := new(validationTestQuery)
+				if err := ctx.Bind().Query(req); err != nil {
+					return newBindError(err, req)
+				}
+
+				return ctx.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest(tc.method, tc.target, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := router.Test(req)
+			require.NoError(t, err)
+
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			bodyBytes, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(bodyBytes), tc.expectedBody)
+		})
+	}
+}
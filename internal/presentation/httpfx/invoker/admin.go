@@ -0,0 +1,39 @@
+package invoker
+
+import (
+	"context"
+
+	"app/config"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/rs/zerolog"
+	"go.uber.org/fx"
+)
+
+// StartAdminServer serves the operational endpoints on their own listener, when enabled.
+func StartAdminServer(
+	cfg *config.Config, app *fiber.App, logger *zerolog.Logger, lc fx.Lifecycle, shutdowner fx.Shutdowner,
+) {
+	if !cfg.Admin.Enabled() {
+		return
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				if err := app.Listen(cfg.Admin.Host, cfg.Admin.ListenConfig()); err != nil {
+					logger.Error().Err(err).Msg("adminserver.Listen")
+
+					_ = shutdowner.Shutdown()
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			logger.Info().Msg("adminserver.Stop")
+
+			return app.ShutdownWithTimeout(cfg.HTTP.ShutdownTimeout)
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package invoker
+
+import (
+	"context"
+	"fmt"
+
+	"app/config"
+	"app/internal/core/port"
+	logging "app/pkg/logger"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/fx"
+)
+
+// StartAPIKeyUsageFlusher periodically persists the API key last-used times
+// and flushes the remaining ones on shutdown.
+func StartAPIKeyUsageFlusher(
+	cfg *config.Config, service port.APIKeyService, logger *zerolog.Logger, lc fx.Lifecycle,
+) {
+	// the stop hooks run in reverse order, the last flush once the periodic ones are stopped
+	lc.Append(fx.Hook{OnStop: service.FlushUsage})
+
+	runPeriodically(lc, logging.Component(*logger, "api_key_usage"), cfg.APIKey.UsageFlushInterval,
+		func(ctx context.Context) error {
+			if err := service.FlushUsage(ctx); err != nil {
+				return fmt.Errorf("flush api key usage: %w", err)
+			}
+
+			return nil
+		},
+	)
+}
@@ -0,0 +1,31 @@
+package invoker
+
+import (
+	"context"
+	"time"
+
+	"app/config"
+	"app/pkg/health"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/fx"
+)
+
+// DrainOnStop fails the readiness probe as soon as the application stops, and delays the
+// server shutdown so that load balancers stop routing requests first. It must be invoked
+// after StartHTTPServer, hooks are stopped in reverse order.
+func DrainOnStop(cfg *config.Config, registry *health.Registry, logger *zerolog.Logger, lc fx.Lifecycle) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Info().Dur("delay", cfg.Health.DrainDelay).Msg("health: draining")
+			registry.Drain()
+
+			select {
+			case <-time.After(cfg.Health.DrainDelay):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
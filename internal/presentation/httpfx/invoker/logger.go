@@ -0,0 +1,55 @@
+package invoker
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"app/config"
+	logging "app/pkg/logger"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/fx"
+)
+
+// ReloadLogLevelsOnSignal applies the log levels of the configuration again on SIGHUP,
+// so that they can be changed without a restart.
+func ReloadLogLevelsOnSignal(logger *zerolog.Logger, lc fx.Lifecycle) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			signal.Notify(signals, syscall.SIGHUP)
+
+			go func() {
+				defer close(done)
+
+				for range signals {
+					cfg, err := config.NewLogger()
+					if err == nil {
+						err = logging.Reload(cfg)
+					}
+
+					if err != nil {
+						logger.Error().Err(err).Msg("reload log levels")
+
+						continue
+					}
+
+					logger.Info().Str("level", cfg.LogLevel.String()).Msg("log levels reloaded")
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			signal.Stop(signals)
+			close(signals)
+			<-done
+
+			return nil
+		},
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"app/config"
 	"app/database/migrations"
+	logging "app/pkg/logger"
 	"app/pkg/logger/adapter/zerogoose"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -14,7 +15,7 @@ import (
 )
 
 func RunMigrations(cfg *config.Config, logger *zerolog.Logger) error {
-	log := logger.With().Str("component", "migrator").Logger()
+	log := logging.Component(*logger, "migrator")
 
 	db, err := goose.OpenDBWithDriver("pgx", cfg.Postgres.DSN())
 	if err != nil {
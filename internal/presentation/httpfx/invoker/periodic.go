@@ -0,0 +1,46 @@
+package invoker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/fx"
+)
+
+// runPeriodically runs fn every interval while the application runs, logging its errors. The hook
+// stopping it returns once the last run is done.
+func runPeriodically(lc fx.Lifecycle, log zerolog.Logger, interval time.Duration, fn func(context.Context) error) {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ticker.C:
+						if err := fn(context.Background()); err != nil {
+							log.Error().Err(err).Msg("run periodic job")
+						}
+					case <-stop:
+						return
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			close(stop)
+			<-done
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,38 @@
+package invoker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"app/internal/core/port"
+	logging "app/pkg/logger"
+	"app/pkg/ratelimit"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/fx"
+)
+
+// StartRateLimitCleanup periodically deletes the expired rate limit keys.
+func StartRateLimitCleanup(
+	cfg ratelimit.Config, store port.RateLimitStore, logger *zerolog.Logger, lc fx.Lifecycle,
+) {
+	if !cfg.Enabled {
+		return
+	}
+
+	log := logging.Component(*logger, "rate_limit_cleanup")
+
+	runPeriodically(lc, log, cfg.CleanupInterval, func(ctx context.Context) error {
+		deleted, err := store.DeleteExpired(ctx, time.Now())
+		if err != nil {
+			return fmt.Errorf("delete expired rate limits: %w", err)
+		}
+
+		if deleted > 0 {
+			log.Debug().Int64("deleted", deleted).Msg("deleted expired rate limits")
+		}
+
+		return nil
+	})
+}
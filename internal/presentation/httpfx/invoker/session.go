@@ -0,0 +1,33 @@
+package invoker
+
+import (
+	"context"
+	"fmt"
+
+	"app/config"
+	"app/internal/core/port"
+	logging "app/pkg/logger"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/fx"
+)
+
+// StartSessionCleanup periodically deletes the expired sessions.
+func StartSessionCleanup(
+	cfg *config.Config, service port.SessionService, logger *zerolog.Logger, lc fx.Lifecycle,
+) {
+	log := logging.Component(*logger, "session_cleanup")
+
+	runPeriodically(lc, log, cfg.Session.CleanupInterval, func(ctx context.Context) error {
+		deleted, err := service.DeleteExpired(ctx)
+		if err != nil {
+			return fmt.Errorf("delete expired sessions: %w", err)
+		}
+
+		if deleted > 0 {
+			log.Info().Int64("deleted", deleted).Msg("deleted expired sessions")
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,15 @@
+package invoker
+
+import (
+	"log/slog"
+)
+
+// SetupSlog makes the default slog logger write to the zerolog logger. It is left alone when the
+// logger is itself built on a slog handler, which would otherwise log into itself.
+func SetupSlog(logger *slog.Logger, handler slog.Handler) {
+	if handler != nil {
+		return
+	}
+
+	slog.SetDefault(logger)
+}
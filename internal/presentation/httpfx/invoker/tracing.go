@@ -0,0 +1,14 @@
+package invoker
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SetupTracing registers the tracer provider and the propagator globally, for the libraries
+// instrumented with OpenTelemetry.
+func SetupTracing(provider trace.TracerProvider, propagator propagation.TextMapPropagator) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+
+	"app/config"
+	"app/internal/core/entity"
+	"app/pkg/argon2id"
+	"app/pkg/jwt"
+
+	"github.com/rs/zerolog"
+)
+
+const ephemeralJWTKeyID = "ephemeral"
+
+// NewAuthPolicies passes the auth settings to the auth service as plain values.
+func NewAuthPolicies(cfg *config.Config) (entity.LockoutPolicy, entity.TokenPolicy) {
+	lockout := entity.LockoutPolicy{
+		MaxFailedAttempts: cfg.Auth.LockoutMaxFailedAttempts,
+		Duration:          cfg.Auth.LockoutDuration,
+	}
+	tokens := entity.TokenPolicy{
+		AccessTTL:  cfg.Auth.AccessTokenTTL,
+		RefreshTTL: cfg.Auth.RefreshTokenTTL,
+	}
+
+	return lockout, tokens
+}
+
+func NewSessionPolicy(cfg *config.Config) entity.SessionPolicy {
+	return entity.SessionPolicy{
+		IdleTimeout:   cfg.Session.IdleTimeout,
+		MaxLifetime:   cfg.Session.MaxLifetime,
+		TouchInterval: cfg.Session.TouchInterval,
+	}
+}
+
+func NewPasswordHasher(cfg *config.Config) *argon2id.Hasher {
+	return argon2id.NewHasher(cfg.Argon2)
+}
+
+// NewJWTManager falls back to a random key when none is configured, which keeps local
+// setups working but invalidates every issued token on restart.
+func NewJWTManager(cfg *config.Config, log *zerolog.Logger) (*jwt.Manager, error) {
+	jwtCfg := cfg.JWT
+
+	if len(jwtCfg.Keys) == 0 {
+		key, err := jwt.GenerateKey(jwtCfg.Algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("generate ephemeral jwt key: %w", err)
+		}
+
+		log.Warn().Msg("JWT_KEYS is not set, signing tokens with an ephemeral key")
+
+		jwtCfg.Keys = map[string]string{ephemeralJWTKeyID: key}
+		jwtCfg.ActiveKeyID = ephemeralJWTKeyID
+	}
+
+	manager, err := jwt.New(jwtCfg)
+	if err != nil {
+		return nil, fmt.Errorf("init jwt manager: %w", err)
+	}
+
+	return manager, nil
+}
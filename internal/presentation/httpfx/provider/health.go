@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"app/config"
+	"app/database/migrations"
+	"app/pkg/health"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/fx"
+)
+
+func NewHealthRegistry(cfg *config.Config, checks []health.Check) (*health.Registry, error) {
+	registry, err := health.NewRegistry(cfg.Health, checks...)
+	if err != nil {
+		return nil, fmt.Errorf("init health registry: %w", err)
+	}
+
+	return registry, nil
+}
+
+func NewPostgresHealthCheck(pool *pgxpool.Pool) health.Check {
+	return health.Check{
+		Name: "postgres",
+		Func: pool.Ping,
+	}
+}
+
+// NewMigrationsHealthCheck fails while the database schema is behind the embedded migrations.
+func NewMigrationsHealthCheck(pool *pgxpool.Pool, lc fx.Lifecycle) (health.Check, error) {
+	db := stdlib.OpenDBFromPool(pool)
+
+	migrator, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS, goose.WithTableName("migrations"))
+	if err != nil {
+		_ = db.Close()
+
+		return health.Check{}, fmt.Errorf("init migration provider: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			return db.Close()
+		},
+	})
+
+	return health.Check{
+		Name: "migrations",
+		Func: func(ctx context.Context) error {
+			current, target, err := migrator.GetVersions(ctx)
+			if err != nil {
+				return fmt.Errorf("get versions: %w", err)
+			}
+
+			if current < target {
+				return fmt.Errorf("database version %d is behind %d", current, target)
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+func NewDependencyHealthChecks(cfg *config.Config) []health.Check {
+	checks := make([]health.Check, 0, len(cfg.Health.Dependencies))
+	for name, url := range cfg.Health.Dependencies {
+		checks = append(checks, health.HTTPCheck(name, url, http.DefaultClient))
+	}
+
+	return checks
+}
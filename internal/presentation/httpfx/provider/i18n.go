@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"fmt"
+
+	"app/config"
+	"app/locales"
+	"app/pkg/i18n"
+)
+
+func NewTranslations(cfg *config.Config) (*i18n.Bundle, error) {
+	bundle, err := i18n.NewBundle(cfg.I18N)
+	if err != nil {
+		return nil, fmt.Errorf("init translations: %w", err)
+	}
+
+	if err := bundle.LoadFS(locales.FS); err != nil {
+		return nil, fmt.Errorf("load translations: %w", err)
+	}
+
+	return bundle, nil
+}
@@ -2,18 +2,45 @@ package provider
 
 import (
 	"fmt"
+	"log/slog"
 
 	"app/config"
 	"app/pkg/logger"
+	"app/pkg/logger/adapter/zerofx"
+	"app/pkg/logger/adapter/zeroslog"
 
 	"github.com/rs/zerolog"
+	"go.uber.org/fx/fxevent"
 )
 
-func NewLogger(cfg *config.Config) (*zerolog.Logger, error) {
-	log, err := logger.New(cfg.Logger)
+// NewLogger builds the logger on the provided slog handler if any, on the configured outputs otherwise.
+func NewLogger(cfg *config.Config, handler slog.Handler) (*zerolog.Logger, error) {
+	var (
+		log *zerolog.Logger
+		err error
+	)
+
+	if handler != nil {
+		log, err = logger.NewWithHandler(cfg.Logger, handler)
+	} else {
+		log, err = logger.New(cfg.Logger)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("init logger: %w", err)
 	}
 
 	return log, nil
 }
+
+// NewSlogLogger returns a slog logger writing to the logger, for the libraries logging through slog.
+func NewSlogLogger(logger *zerolog.Logger) *slog.Logger {
+	return slog.New(zeroslog.NewHandler(logger))
+}
+
+// NewFxLogger returns the logger of the fx events, under the fx component.
+func NewFxLogger(log *zerolog.Logger) fxevent.Logger {
+	fxLogger := logger.Component(*log, "fx")
+
+	return zerofx.NewLogger(&fxLogger)
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"fmt"
+
+	"app/config"
+	"app/internal/core/port"
+	"app/internal/infra/repository/postgres"
+	"app/pkg/ratelimit"
+	pgxTransactor "app/pkg/transactor/pgx"
+)
+
+func NewRateLimitStore(cfg *config.Config, dbGetter pgxTransactor.DBGetter) (port.RateLimitStore, error) {
+	switch cfg.RateLimit.Store {
+	case ratelimit.StoreMemory:
+		return ratelimit.NewMemoryStore(), nil
+	case ratelimit.StorePostgres:
+		return postgres.NewRateLimitRepository(dbGetter), nil
+	default:
+		return nil, fmt.Errorf("init rate limit store: %w: %s", ratelimit.ErrUnknownStore, cfg.RateLimit.Store)
+	}
+}
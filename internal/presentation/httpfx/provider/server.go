@@ -5,21 +5,35 @@ import (
 
 	"app/config"
 	"app/internal/presentation/httpfx/handler"
+	"app/pkg/errtrace"
 	"app/pkg/httpserver"
 
 	fiberzerolog "github.com/gofiber/contrib/v3/zerolog"
 	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func NewServer(
 	cfg *config.Config, logger *zerolog.Logger,
+	tracerProvider trace.TracerProvider, propagator propagation.TextMapPropagator, metrics *httpserver.Metrics,
 ) (*fiber.App, error) {
-	app, err := httpserver.New(cfg.HTTP, handler.ErrorHandler)
+	errorHandler := handler.ErrorHandler
+	if cfg.HTTP.DebugErrors {
+		errorHandler = handler.DebugErrorHandler
+	}
+
+	app, err := httpserver.New(cfg.HTTP, errorHandler, handler.StructValidator())
 	if err != nil {
 		return nil, fmt.Errorf("new http server: %w", err)
 	}
 
+	// the access log answers the errors with the error handler, the metrics and the tracing wrapping it
+	// read the final status of the response
+	app.Use(metrics.Handler())
+	app.Use(httpserver.Tracing(tracerProvider, propagator))
 	app.Use(fiberzerolog.New(fiberzerolog.Config{
 		Fields: []string{
 			fiberzerolog.FieldLatency,
@@ -27,13 +41,57 @@ func NewServer(
 			fiberzerolog.FieldMethod,
 			fiberzerolog.FieldURL,
 			fiberzerolog.FieldIP,
-			fiberzerolog.FieldRequestID,
 			fiberzerolog.FieldError,
 		},
+		// the request logger carries the route
 		GetLogger: func(ctx fiber.Ctx) zerolog.Logger {
-			return *logger
+			requestLogger := handler.RequestLoggerFromCtx(ctx)
+			if requestLogger == nil {
+				requestLogger = logger
+			}
+
+			return internalErrorLogger(ctx, *requestLogger)
 		},
 	}))
+	app.Use(handler.RequestLogger(logger))
+
+	return app, nil
+}
+
+// internalErrorLogger adds the cause chain and stack trace of an unhandled error to the request logger,
+// the error itself is logged by the middleware.
+func internalErrorLogger(ctx fiber.Ctx, logger zerolog.Logger) zerolog.Logger {
+	err := handler.InternalError(ctx)
+	if err == nil {
+		return logger
+	}
+
+	logCtx := logger.With().
+		Strs("causes", errtrace.Chain(err)).
+		Str("user_agent", ctx.Get(fiber.HeaderUserAgent))
+
+	if trace := errtrace.StackTrace(err); trace != nil {
+		logCtx = logCtx.Interface("stack", trace)
+	}
+
+	return logCtx.Logger()
+}
+
+// NewHTTPMetrics registers the metrics of the API server, exported on the admin listener.
+func NewHTTPMetrics() (*httpserver.Metrics, error) {
+	metrics, err := httpserver.NewMetrics(prometheus.DefaultRegisterer, handler.ErrorCode)
+	if err != nil {
+		return nil, fmt.Errorf("init http metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+func NewAdminServer(cfg *config.Config) (*fiber.App, error) {
+	app, err := httpserver.NewAdmin(cfg.Admin, handler.ErrorHandler, handler.StructValidator())
+	if err != nil {
+		return nil, fmt.Errorf("new admin server: %w", err)
+	}
 
 	return app, nil
 }
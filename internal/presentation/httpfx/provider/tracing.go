@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"app/config"
+	"app/pkg/tracing"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+func NewTracerProvider(cfg *config.Config, logger *zerolog.Logger, lc fx.Lifecycle) (trace.TracerProvider, error) {
+	provider, err := tracing.NewTracerProvider(cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("init tracer provider: %w", err)
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			logger.Info().Msg("tracing: flushing spans")
+
+			return provider.Shutdown(ctx)
+		},
+	})
+
+	return provider, nil
+}
@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"app/config"
+	"app/internal/core/entity"
+)
+
+func NewImportPolicy(cfg *config.Config) entity.ImportPolicy {
+	return entity.ImportPolicy{
+		MaxSize: cfg.Import.MaxSize,
+		MaxRows: cfg.Import.MaxRows,
+	}
+}
@@ -1,45 +1,104 @@
 package httpfx
 
 import (
+	"log/slog"
+
 	"app/config"
 	"app/internal/core"
 	"app/internal/core/port"
+	"app/internal/core/service/apikey"
+	"app/internal/core/service/auth"
+	"app/internal/core/service/authorization"
+	"app/internal/core/service/session"
 	"app/internal/core/service/user"
 	"app/internal/infra/repository/postgres"
+	"app/internal/infra/token"
 	"app/internal/presentation/httpfx/handler"
 	"app/internal/presentation/httpfx/invoker"
 	"app/internal/presentation/httpfx/provider"
+	"app/pkg/tracing"
 
 	"go.uber.org/fx"
 )
 
+// logging provides the loggers, built on the handler of WithSlogHandler when the application has one.
+var logging = fx.Options(
+	fx.Provide(fx.Annotate(provider.NewLogger, fx.ParamTags(``, `optional:"true"`))),
+	fx.Provide(provider.NewSlogLogger),
+	fx.Invoke(fx.Annotate(invoker.SetupSlog, fx.ParamTags(``, `optional:"true"`))),
+)
+
+// WithSlogHandler makes the application log to the slog handler instead of the configured outputs,
+// e.g. fx.New(httpfx.CreateApp(&cfg), httpfx.WithSlogHandler(handler)).
+func WithSlogHandler(handler slog.Handler) fx.Option {
+	return fx.Provide(func() slog.Handler { return handler })
+}
+
 func CreateApp(cfg *config.Config) fx.Option {
 	return fx.Options(
-		// fx.NopLogger,
+		fx.WithLogger(provider.NewFxLogger),
 		fx.Supply(cfg),
+		fx.Supply(cfg.RateLimit),
 
 		// Provide infrastructure
-		fx.Provide(provider.NewLogger),
+		logging,
 		fx.Provide(provider.NewPgxPool),
 		fx.Provide(provider.NewPgxTransactor),
+		fx.Provide(provider.NewTracerProvider),
+		fx.Provide(tracing.NewPropagator),
+		fx.Provide(provider.NewHTTPMetrics),
 		fx.Provide(provider.NewServer),
+		fx.Provide(fx.Annotate(provider.NewAdminServer, fx.ResultTags(`name:"admin"`))),
+		fx.Provide(provider.NewAuthPolicies),
+		fx.Provide(provider.NewSessionPolicy),
+		fx.Provide(provider.NewImportPolicy),
+		fx.Provide(fx.Annotate(provider.NewPasswordHasher, fx.As(new(port.PasswordHasher)))),
+		fx.Provide(provider.NewJWTManager),
+		fx.Provide(provider.NewTranslations),
+		fx.Provide(provider.NewRateLimitStore),
+		fx.Provide(fx.Annotate(provider.NewHealthRegistry, fx.ParamTags(``, `group:"health_checks"`))),
+		fx.Provide(fx.Annotate(token.NewJWTIssuer, fx.As(new(port.AccessTokenIssuer)))),
+
+		// Provide health checks
+		fx.Provide(fx.Annotate(provider.NewPostgresHealthCheck, fx.ResultTags(`group:"health_checks"`))),
+		fx.Provide(fx.Annotate(provider.NewMigrationsHealthCheck, fx.ResultTags(`group:"health_checks"`))),
+		fx.Provide(fx.Annotate(provider.NewDependencyHealthChecks, fx.ResultTags(`group:"health_checks,flatten"`))),
 
 		// Provide ports
 		fx.Provide(fx.Annotate(postgres.NewUserRepository, fx.As(new(port.UserRepository)))),
+		fx.Provide(fx.Annotate(postgres.NewCredentialsRepository, fx.As(new(port.CredentialsRepository)))),
+		fx.Provide(fx.Annotate(postgres.NewRefreshTokenRepository, fx.As(new(port.RefreshTokenRepository)))),
+		fx.Provide(fx.Annotate(postgres.NewPermissionRepository, fx.As(new(port.PermissionRepository)))),
+		fx.Provide(fx.Annotate(postgres.NewAPIKeyRepository, fx.As(new(port.APIKeyRepository)))),
+		fx.Provide(fx.Annotate(postgres.NewSessionRepository, fx.As(new(port.SessionRepository)))),
 
 		// Provide services
 		fx.Provide(fx.Annotate(user.NewService, fx.As(new(port.UserService)))),
+		fx.Provide(fx.Annotate(auth.NewService, fx.As(new(port.AuthService)))),
+		fx.Provide(fx.Annotate(authorization.NewService, fx.As(new(port.Authorizer)))),
+		fx.Provide(fx.Annotate(apikey.NewService, fx.As(new(port.APIKeyService)))),
+		fx.Provide(fx.Annotate(session.NewService, fx.As(new(port.SessionService)))),
 
 		// Provide core
 		fx.Provide(core.NewApplication),
 
 		// Provide http handlers
 		fx.Provide(handler.NewHandler),
+		fx.Provide(handler.NewRateLimiter),
 
 		fx.Invoke(invoker.SetupTimezone),
+		fx.Invoke(invoker.SetupTracing),
+		fx.Invoke(invoker.ReloadLogLevelsOnSignal),
 		fx.Invoke(invoker.RunMigrations),
 
+		fx.Invoke(invoker.StartAPIKeyUsageFlusher),
+		fx.Invoke(invoker.StartSessionCleanup),
+		fx.Invoke(invoker.StartRateLimitCleanup),
+
 		fx.Invoke(handler.ApplyRoutes),
 		fx.Invoke(invoker.StartHTTPServer),
+		fx.Invoke(fx.Annotate(handler.ApplyAdminRoutes, fx.ParamTags(`name:"admin"`))),
+		fx.Invoke(fx.Annotate(invoker.StartAdminServer, fx.ParamTags(``, `name:"admin"`))),
+		fx.Invoke(invoker.DrainOnStop),
 	)
 }
@@ -0,0 +1,54 @@
+package httpfx
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"app/config"
+	"app/pkg/logger"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestWithSlogHandler(t *testing.T) {
+	level, contextLogger, defaultLogger := zerolog.GlobalLevel(), zerolog.DefaultContextLogger, slog.Default()
+	t.Cleanup(func() {
+		zerolog.SetGlobalLevel(level)
+		zerolog.DefaultContextLogger = contextLogger
+	})
+
+	var buf bytes.Buffer
+
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+
+	cfg := config.Config{Logger: logger.Config{LogLevel: zerolog.InfoLevel, LevelOverrideTTL: time.Hour}}
+
+	app := fxtest.New(t,
+		fx.NopLogger,
+		fx.Supply(&cfg),
+		logging,
+		WithSlogHandler(handler),
+		fx.Invoke(func(log *zerolog.Logger) {
+			log.Debug().Msg("skipped")
+			log.Info().Str("user", "john").Msg("started")
+		}),
+	)
+	app.RequireStart().RequireStop()
+
+	assert.Equal(t, "level=INFO msg=started user=john\n", buf.String())
+	// the default slog logger is not routed to the logger writing to the handler
+	assert.Same(t, defaultLogger, slog.Default())
+}
@@ -0,0 +1,8 @@
+// Package locales embeds the translations of the API messages, keyed by error code.
+// English messages are the ones of the code, so English needs no translation file.
+package locales
+
+import "embed"
+
+//go:embed *.toml *.json
+var FS embed.FS
@@ -0,0 +1,59 @@
+package locales_test
+
+import (
+	"testing"
+
+	_ "app/internal/core/entity"
+	domainErrors "app/internal/core/error"
+	_ "app/internal/core/port"
+	"app/locales"
+	"app/pkg/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var validationKeys = []string{
+	"validation.required",
+	"validation.regex",
+	"validation.uuid",
+	"validation.max",
+	"validation.max.characters",
+	"validation.max.items",
+	"validation.min",
+	"validation.min.characters",
+	"validation.min.items",
+	"validation.type",
+	"validation.type.array",
+	"validation.type.boolean",
+	"validation.type.number",
+	"validation.type.object",
+	"validation.type.string",
+}
+
+func TestFS_Complete(t *testing.T) {
+	t.Parallel()
+
+	bundle, err := i18n.NewBundle(i18n.Config{DefaultLocale: "en"})
+	require.NoError(t, err)
+	require.NoError(t, bundle.LoadFS(locales.FS))
+
+	keys := append([]string{}, validationKeys...)
+	for _, def := range domainErrors.Definitions() {
+		keys = append(keys, def.Code)
+	}
+
+	for _, locale := range []string{"uk", "de"} {
+		t.Run(locale, func(t *testing.T) {
+			t.Parallel()
+
+			localizer := bundle.Localizer(locale)
+			require.Equal(t, locale, localizer.Language().String())
+
+			for _, key := range keys {
+				_, ok := localizer.Translate(key, nil)
+				assert.True(t, ok, "missing translation for %s", key)
+			}
+		})
+	}
+}
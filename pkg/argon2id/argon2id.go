@@ -0,0 +1,105 @@
+package argon2id
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrInvalidHash is returned when an encoded hash is not in the argon2id PHC string format.
+var ErrInvalidHash = errors.New("invalid argon2id hash")
+
+// Config holds the argon2id parameters used for new hashes.
+type Config struct {
+	Memory      uint32 `env:"ARGON2_MEMORY_KIB" envDefault:"65536"`
+	Iterations  uint32 `env:"ARGON2_ITERATIONS" envDefault:"3"`
+	Parallelism uint8  `env:"ARGON2_PARALLELISM" envDefault:"2"`
+	SaltLength  uint32 `env:"ARGON2_SALT_LENGTH" envDefault:"16"`
+	KeyLength   uint32 `env:"ARGON2_KEY_LENGTH" envDefault:"32"`
+}
+
+// Hasher hashes passwords with argon2id and encodes them as PHC strings:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+type Hasher struct {
+	cfg Config
+}
+
+func NewHasher(cfg Config) *Hasher {
+	return &Hasher{cfg: cfg}
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.cfg.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.cfg.Iterations, h.cfg.Memory, h.cfg.Parallelism, h.cfg.KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.cfg.Memory, h.cfg.Iterations, h.cfg.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify reports whether the password matches the encoded hash and whether the hash
+// was produced with parameters different from the current configuration.
+func (h *Hasher) Verify(password, encodedHash string) (bool, bool, error) {
+	params, salt, key, err := decode(encodedHash)
+	if err != nil {
+		return false, false, err
+	}
+
+	//nolint:gosec // key length is bounded by the decoded hash
+	otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return false, false, nil
+	}
+
+	//nolint:gosec // salt length is bounded by the decoded hash
+	params.SaltLength = uint32(len(salt))
+	//nolint:gosec // key length is bounded by the decoded hash
+	params.KeyLength = uint32(len(key))
+
+	return true, params != h.cfg, nil
+}
+
+func decode(encodedHash string) (Config, []byte, []byte, error) {
+	var params Config
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: unsupported version", ErrInvalidHash)
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: parameters: %w", ErrInvalidHash, err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: salt: %w", ErrInvalidHash, err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: key: %w", ErrInvalidHash, err)
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,66 @@
+package argon2id
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHasher_HashAndVerify(t *testing.T) {
+	t.Parallel()
+
+	hasher := NewHasher(testConfig)
+
+	hash, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$m=1024,t=1,p=1$")
+
+	match, rehash, err := hasher.Verify("correct horse", hash)
+	require.NoError(t, err)
+	assert.True(t, match)
+	assert.False(t, rehash)
+
+	match, _, err = hasher.Verify("battery staple", hash)
+	require.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestHasher_VerifyNeedsRehash(t *testing.T) {
+	t.Parallel()
+
+	hash, err := NewHasher(testConfig).Hash("correct horse")
+	require.NoError(t, err)
+
+	stronger := testConfig
+	stronger.Iterations = 2
+
+	match, rehash, err := NewHasher(stronger).Verify("correct horse", hash)
+	require.NoError(t, err)
+	assert.True(t, match)
+	assert.True(t, rehash)
+}
+
+func TestHasher_VerifyInvalidHash(t *testing.T) {
+	t.Parallel()
+
+	hasher := NewHasher(testConfig)
+
+	for _, hash := range []string{
+		"",
+		"$2a$10$abcdefghijklmnopqrstuv",
+		"$argon2id$v=18$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$a2V5",
+	} {
+		_, _, err := hasher.Verify("password", hash)
+		assert.ErrorIs(t, err, ErrInvalidHash, hash)
+	}
+}
@@ -0,0 +1,120 @@
+// Package errtrace records the stack trace of an error where it is created or first wrapped,
+// so that unexpected errors can be logged with the code path that produced them.
+package errtrace
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+const maxDepth = 32
+
+// Frame is a single call of a stack trace.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+func (f Frame) String() string {
+	return fmt.Sprintf("%s (%s:%d)", f.Function, f.File, f.Line)
+}
+
+type stackError struct {
+	err   error
+	stack []uintptr
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// New returns an error with the message and the stack of the caller.
+func New(message string) error {
+	return &stackError{err: errors.New(message), stack: callers()}
+}
+
+// Errorf formats the error like fmt.Errorf and records the stack of the caller,
+// unless one of the wrapped errors already carries a stack.
+func Errorf(format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	if hasStack(err) {
+		return err
+	}
+
+	return &stackError{err: err, stack: callers()}
+}
+
+// Wrap records the stack of the caller on the error, unless it already carries one.
+func Wrap(err error) error {
+	if err == nil || hasStack(err) {
+		return err
+	}
+
+	return &stackError{err: err, stack: callers()}
+}
+
+// StackTrace returns the stack recorded in the error chain, or nil when there is none.
+func StackTrace(err error) []Frame {
+	stackErr, ok := errors.AsType[*stackError](err)
+	if !ok {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(stackErr.stack)
+	trace := make([]Frame, 0, len(stackErr.stack))
+
+	for {
+		frame, more := frames.Next()
+		trace = append(trace, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+
+		if !more {
+			return trace
+		}
+	}
+}
+
+// Chain returns the messages of the error and of every error it wraps, depth first.
+func Chain(err error) []string {
+	var chain []string
+
+	for err != nil {
+		if _, ok := err.(*stackError); !ok {
+			chain = append(chain, err.Error())
+		}
+
+		switch wrapped := err.(type) {
+		case interface{ Unwrap() error }:
+			err = wrapped.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, joined := range wrapped.Unwrap() {
+				chain = append(chain, Chain(joined)...)
+			}
+
+			return chain
+		default:
+			return chain
+		}
+	}
+
+	return chain
+}
+
+func hasStack(err error) bool {
+	_, ok := errors.AsType[*stackError](err)
+
+	return ok
+}
+
+func callers() []uintptr {
+	pcs := make([]uintptr, maxDepth)
+	// skip runtime.Callers, callers and the errtrace function
+	n := runtime.Callers(3, pcs)
+
+	return pcs[:n]
+}
@@ -0,0 +1,102 @@
+package errtrace
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRoot = errors.New("connection refused")
+
+func TestErrorf(t *testing.T) {
+	t.Parallel()
+
+	err := Errorf("execute query: %w", errRoot)
+
+	require.ErrorIs(t, err, errRoot)
+	assert.Equal(t, "execute query: connection refused", err.Error())
+
+	trace := StackTrace(err)
+	require.NotEmpty(t, trace)
+	assert.Equal(t, "app/pkg/errtrace.TestErrorf", trace[0].Function)
+	assert.Contains(t, trace[0].File, "errtrace_test.go")
+	assert.Positive(t, trace[0].Line)
+}
+
+func TestErrorf_KeepsOrigin(t *testing.T) {
+	t.Parallel()
+
+	origin := New("no rows")
+	err := Errorf("find user: %w", Errorf("execute query: %w", origin))
+
+	assert.Equal(t, StackTrace(origin), StackTrace(err))
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Wrap(nil))
+
+	err := Wrap(errRoot)
+	require.ErrorIs(t, err, errRoot)
+	assert.NotEmpty(t, StackTrace(err))
+	assert.Equal(t, err, Wrap(err))
+}
+
+func TestStackTrace_WithoutStack(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, StackTrace(errRoot))
+	assert.Nil(t, StackTrace(nil))
+}
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{
+			name:     "Nil",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "Single",
+			err:      errRoot,
+			expected: []string{"connection refused"},
+		},
+		{
+			name: "Wrapped",
+			err:  fmt.Errorf("create user: %w", Errorf("execute query: %w", errRoot)),
+			expected: []string{
+				"create user: execute query: connection refused",
+				"execute query: connection refused",
+				"connection refused",
+			},
+		},
+		{
+			name: "Joined",
+			err:  fmt.Errorf("rollback: %w", errors.Join(errRoot, New("tx closed"))),
+			expected: []string{
+				"rollback: connection refused\ntx closed",
+				"connection refused\ntx closed",
+				"connection refused",
+				"tx closed",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, Chain(tc.err))
+		})
+	}
+}
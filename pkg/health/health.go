@@ -0,0 +1,249 @@
+// Package health runs the checks behind the liveness, readiness and health probes.
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Config struct {
+	// Timeout bounds a check that does not define its own timeout.
+	Timeout time.Duration `env:"HEALTH_CHECK_TIMEOUT" envDefault:"2s"`
+	// CacheTTL is how long a check result is reused, so that frequent probes do not load the dependencies.
+	CacheTTL time.Duration `env:"HEALTH_CACHE_TTL" envDefault:"1s"`
+	// DrainDelay is how long the server keeps serving with a failing readiness before it shuts down,
+	// long enough for load balancers to stop routing to it.
+	DrainDelay time.Duration `env:"HEALTH_DRAIN_DELAY" envDefault:"5s"`
+	// Dependencies are the outbound HTTP services checked by URL, e.g. HEALTH_DEPENDENCIES=billing:http://billing/livez.
+	Dependencies map[string]string `env:"HEALTH_DEPENDENCIES" envKeyValSeparator:":" envSeparator:","`
+}
+
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusFailing  Status = "failing"
+	StatusDraining Status = "draining"
+)
+
+// Check is a single named check. Readiness runs every check, liveness only the ones marked as such,
+// since a failing dependency is no reason to restart the process.
+type Check struct {
+	Name     string
+	Liveness bool
+	// Timeout overrides Config.Timeout when set.
+	Timeout time.Duration
+	Func    func(ctx context.Context) error
+}
+
+type Result struct {
+	Status    Status    `json:"status" example:"ok"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration" example:"1.2ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type Report struct {
+	Status Status            `json:"status" example:"ok"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// OK reports whether the probe passed.
+func (r *Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// cachedResult is the result of a check, shared by the probes arriving while it runs.
+type cachedResult struct {
+	// done is closed once the result and its expiry are set.
+	done    chan struct{}
+	result  Result
+	expires time.Time
+}
+
+// fresh reports whether the check ran and its result is still valid, or is still running.
+func (c *cachedResult) fresh(now time.Time) bool {
+	select {
+	case <-c.done:
+		return now.Before(c.expires)
+	default:
+		return true
+	}
+}
+
+type Registry struct {
+	cfg      Config
+	checks   []Check
+	draining atomic.Bool
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*cachedResult
+}
+
+func NewRegistry(cfg Config, checks ...Check) (*Registry, error) {
+	names := make(map[string]struct{}, len(checks))
+
+	for _, check := range checks {
+		if check.Name == "" || check.Func == nil {
+			return nil, fmt.Errorf("invalid health check %q", check.Name)
+		}
+
+		if _, ok := names[check.Name]; ok {
+			return nil, fmt.Errorf("duplicate health check %q", check.Name)
+		}
+
+		names[check.Name] = struct{}{}
+	}
+
+	return &Registry{
+		cfg:    cfg,
+		checks: checks,
+		now:    time.Now,
+		cache:  make(map[string]*cachedResult, len(checks)),
+	}, nil
+}
+
+// Drain makes the readiness probe fail from now on.
+func (r *Registry) Drain() {
+	r.draining.Store(true)
+}
+
+// Live runs the liveness checks.
+func (r *Registry) Live(ctx context.Context) *Report {
+	var checks []Check
+
+	for _, check := range r.checks {
+		if check.Liveness {
+			checks = append(checks, check)
+		}
+	}
+
+	return r.run(ctx, checks)
+}
+
+// Ready runs every check and fails while draining.
+func (r *Registry) Ready(ctx context.Context) *Report {
+	if r.draining.Load() {
+		return &Report{Status: StatusDraining}
+	}
+
+	return r.run(ctx, r.checks)
+}
+
+// Health runs every check regardless of draining, for a detailed view of the dependencies.
+func (r *Registry) Health(ctx context.Context) *Report {
+	return r.run(ctx, r.checks)
+}
+
+func (r *Registry) run(ctx context.Context, checks []Check) *Report {
+	report := &Report{
+		Status: StatusOK,
+		Checks: make(map[string]Result, len(checks)),
+	}
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+
+	for i, check := range checks {
+		wg.Go(func() {
+			results[i] = r.result(ctx, check)
+		})
+	}
+
+	wg.Wait()
+
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFailing
+		}
+	}
+
+	return report
+}
+
+// result runs the check once per cache TTL, concurrent probes waiting for the running check
+// rather than running it again.
+func (r *Registry) result(ctx context.Context, check Check) Result {
+	r.mu.Lock()
+
+	cached, ok := r.cache[check.Name]
+	if ok && cached.fresh(r.now()) {
+		r.mu.Unlock()
+
+		select {
+		case <-cached.done:
+			return cached.result
+		case <-ctx.Done():
+			return Result{Status: StatusFailing, Error: ctx.Err().Error(), CheckedAt: r.now()}
+		}
+	}
+
+	cached = &cachedResult{done: make(chan struct{})}
+	r.cache[check.Name] = cached
+	r.mu.Unlock()
+
+	// the result is shared, so it does not depend on the cancellation of the probe running it
+	cached.result = r.check(context.WithoutCancel(ctx), check)
+	cached.expires = cached.result.CheckedAt.Add(r.cfg.CacheTTL)
+	close(cached.done)
+
+	return cached.result
+}
+
+func (r *Registry) check(ctx context.Context, check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = r.cfg.Timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := r.now()
+	err := check.Func(ctx)
+
+	result := Result{
+		Status:    StatusOK,
+		Duration:  r.now().Sub(start).String(),
+		CheckedAt: start,
+	}
+
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// HTTPCheck checks that the URL answers with a 2xx status.
+func HTTPCheck(name, url string, client *http.Client) Check {
+	return Check{
+		Name: name,
+		Func: func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return fmt.Errorf("new request: %w", err)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return fmt.Errorf("do request: %w", err)
+			}
+
+			_ = resp.Body.Close()
+
+			if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+				return fmt.Errorf("unexpected status %d", resp.StatusCode)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,195 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{Timeout: 50 * time.Millisecond, CacheTTL: time.Minute}
+
+func okCheck(name string) Check {
+	return Check{Name: name, Func: func(context.Context) error { return nil }}
+}
+
+func TestNewRegistry(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		checks []Check
+		errMsg string
+	}{
+		{name: "Valid", checks: []Check{okCheck("postgres"), okCheck("migrations")}},
+		{name: "Missing Name", checks: []Check{okCheck("")}, errMsg: `invalid health check ""`},
+		{name: "Missing Func", checks: []Check{{Name: "postgres"}}, errMsg: `invalid health check "postgres"`},
+		{
+			name:   "Duplicate",
+			checks: []Check{okCheck("postgres"), okCheck("postgres")},
+			errMsg: `duplicate health check "postgres"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewRegistry(testConfig, tc.checks...)
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegistry_Probes(t *testing.T) {
+	t.Parallel()
+
+	registry, err := NewRegistry(testConfig,
+		Check{Name: "event_loop", Liveness: true, Func: func(context.Context) error { return nil }},
+		Check{Name: "postgres", Func: func(context.Context) error { return errors.New("connection refused") }},
+		Check{
+			Name:    "billing",
+			Timeout: time.Millisecond,
+			Func: func(ctx context.Context) error {
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	live := registry.Live(t.Context())
+	assert.True(t, live.OK())
+	assert.Equal(t, []string{"event_loop"}, checkNames(live))
+
+	ready := registry.Ready(t.Context())
+	assert.False(t, ready.OK())
+	assert.Equal(t, StatusFailing, ready.Status)
+	assert.Equal(t, StatusOK, ready.Checks["event_loop"].Status)
+	assert.Equal(t, "connection refused", ready.Checks["postgres"].Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), ready.Checks["billing"].Error)
+
+	registry.Drain()
+
+	assert.Equal(t, &Report{Status: StatusDraining}, registry.Ready(t.Context()))
+	assert.Equal(t, StatusFailing, registry.Health(t.Context()).Status)
+	assert.True(t, registry.Live(t.Context()).OK())
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	registry, err := NewRegistry(testConfig, Check{
+		Name: "postgres",
+		Func: func(context.Context) error {
+			calls.Add(1)
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	registry.now = func() time.Time { return now }
+
+	registry.Ready(t.Context())
+	registry.Health(t.Context())
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(testConfig.CacheTTL)
+	registry.Ready(t.Context())
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRegistry_ConcurrentProbes(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+
+	registry, err := NewRegistry(testConfig, Check{
+		Name: "postgres",
+		Func: func(context.Context) error {
+			calls.Add(1)
+			<-release
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	reports := make(chan *Report)
+
+	for range 3 {
+		go func() {
+			reports <- registry.Ready(t.Context())
+		}()
+	}
+
+	// the first probe runs the check, the others wait for it
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+
+	for range 3 {
+		assert.True(t, (<-reports).OK())
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestHTTPCheck(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name   string
+		url    string
+		errMsg string
+	}{
+		{name: "Up", url: server.URL + "/up"},
+		{name: "Down", url: server.URL + "/down", errMsg: "unexpected status 503"},
+		{name: "Invalid URL", url: "://", errMsg: "new request"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := HTTPCheck(tc.name, tc.url, server.Client()).Func(t.Context())
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func checkNames(report *Report) []string {
+	names := make([]string, 0, len(report.Checks))
+	for name := range report.Checks {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,85 @@
+package httpserver
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	recoverMiddleware "github.com/gofiber/fiber/v3/middleware/recover"
+)
+
+var (
+	// ErrAdminUnauthenticated is returned when the admin listener is enabled without any authentication.
+	ErrAdminUnauthenticated = errors.New("admin listener requires a token or mTLS")
+	// ErrAdminTLSIncomplete is returned when the TLS files of the admin listener are only partly set,
+	// which would serve it over plain HTTP.
+	ErrAdminTLSIncomplete = errors.New("admin listener TLS requires both a certificate and a key")
+)
+
+// AdminConfig configures the optional listener of the operational endpoints.
+// Clients authenticate with the static token, a client certificate signed by the client CA, or both.
+type AdminConfig struct {
+	Host            string `env:"ADMIN_HOST" envDefault:""` // disabled when empty
+	Token           string `env:"ADMIN_TOKEN,unset"`
+	TLSCertFile     string `env:"ADMIN_TLS_CERT_FILE"`
+	TLSKeyFile      string `env:"ADMIN_TLS_KEY_FILE"`
+	TLSClientCAFile string `env:"ADMIN_TLS_CLIENT_CA_FILE"`
+}
+
+func (c AdminConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// TLS tells whether the listener serves TLS, which takes both the certificate and its key.
+func (c AdminConfig) TLS() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// MutualTLS tells whether the clients authenticate with a certificate, only verified over TLS.
+func (c AdminConfig) MutualTLS() bool {
+	return c.TLS() && c.TLSClientCAFile != ""
+}
+
+// Validate rejects a listener without authentication or with a partial TLS config.
+func (c AdminConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if !c.TLS() && (c.TLSCertFile != "" || c.TLSKeyFile != "" || c.TLSClientCAFile != "") {
+		return ErrAdminTLSIncomplete
+	}
+
+	if c.Token == "" && !c.MutualTLS() {
+		return ErrAdminUnauthenticated
+	}
+
+	return nil
+}
+
+// ListenConfig enables TLS when a certificate is configured, and mTLS when a client CA is.
+func (c AdminConfig) ListenConfig() fiber.ListenConfig {
+	return fiber.ListenConfig{
+		DisableStartupMessage: true,
+		CertFile:              c.TLSCertFile,
+		CertKeyFile:           c.TLSKeyFile,
+		CertClientFile:        c.TLSClientCAFile,
+	}
+}
+
+func NewAdmin(cfg AdminConfig, errorHandler fiber.ErrorHandler, structValidator fiber.StructValidator) (*fiber.App, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	app := fiber.New(fiber.Config{
+		AppName:         "admin",
+		ErrorHandler:    errorHandler,
+		StructValidator: structValidator,
+	})
+
+	app.Use(recoverMiddleware.New(recoverMiddleware.Config{
+		EnableStackTrace: true,
+	}))
+
+	return app, nil
+}
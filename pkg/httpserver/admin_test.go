@@ -0,0 +1,51 @@
+package httpserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         AdminConfig
+		expectedErr error
+	}{
+		{
+			name: "Disabled",
+			cfg:  AdminConfig{},
+		},
+		{
+			name: "Token",
+			cfg:  AdminConfig{Host: "127.0.0.1:9090", Token: "secret"},
+		},
+		{
+			name: "Mutual TLS",
+			cfg: AdminConfig{
+				Host: "127.0.0.1:9090", TLSCertFile: "cert.pem", TLSKeyFile: "key.pem", TLSClientCAFile: "ca.pem",
+			},
+		},
+		{
+			name:        "No Authentication",
+			cfg:         AdminConfig{Host: "127.0.0.1:9090", TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"},
+			expectedErr: ErrAdminUnauthenticated,
+		},
+		{
+			name:        "Client CA Without Certificate",
+			cfg:         AdminConfig{Host: "127.0.0.1:9090", TLSClientCAFile: "ca.pem"},
+			expectedErr: ErrAdminTLSIncomplete,
+		},
+		{
+			name:        "Certificate Without Key",
+			cfg:         AdminConfig{Host: "127.0.0.1:9090", Token: "secret", TLSCertFile: "cert.pem"},
+			expectedErr: ErrAdminTLSIncomplete,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ErrorIs(t, tc.cfg.Validate(), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package httpserver
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute labels the requests matching no route, whose raw paths would explode the cardinality.
+const unmatchedRoute = "unmatched"
+
+// Metrics are the RED metrics of the HTTP server, labelled by route template and status class.
+type Metrics struct {
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	inFlight      prometheus.Gauge
+	requestSize   *prometheus.HistogramVec
+	responseSize  *prometheus.HistogramVec
+	errorCodes    *prometheus.CounterVec
+	errorCodeFunc func(ctx fiber.Ctx) string
+}
+
+// NewMetrics registers the metrics. errorCode returns the application error code a request was
+// answered with, if any, counted by http_server_error_codes_total.
+func NewMetrics(registerer prometheus.Registerer, errorCode func(ctx fiber.Ctx) string) (*Metrics, error) {
+	sizeBuckets := prometheus.ExponentialBuckets(128, 4, 8) // 128 B to 2 MB
+
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_server_requests_total",
+			Help: "Number of HTTP requests served.",
+		}, []string{"method", "route", "status_class"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_server_request_duration_seconds",
+			Help:    "Duration of the HTTP requests.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status_class"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "http_server_requests_in_flight",
+			Help: "Number of HTTP requests being served.",
+		}),
+		requestSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_server_request_size_bytes",
+			Help:    "Size of the HTTP request bodies.",
+			Buckets: sizeBuckets,
+		}, []string{"method", "route"}),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_server_response_size_bytes",
+			Help:    "Size of the HTTP response bodies, streamed bodies of unknown length excluded.",
+			Buckets: sizeBuckets,
+		}, []string{"method", "route"}),
+		errorCodes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_server_error_codes_total",
+			Help: "Number of HTTP requests answered with an application error code.",
+		}, []string{"method", "route", "code"}),
+		errorCodeFunc: errorCode,
+	}
+
+	for _, collector := range []prometheus.Collector{
+		m.requests, m.duration, m.inFlight, m.requestSize, m.responseSize, m.errorCodes,
+	} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, fmt.Errorf("register http metrics: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// Handler records the metrics of every request once it is answered. The middleware must wrap the one
+// answering the errors with the error handler, the status being read from the response.
+func (m *Metrics) Handler() fiber.Handler {
+	return func(ctx fiber.Ctx) error {
+		start := time.Now()
+
+		m.inFlight.Inc()
+		defer m.inFlight.Dec()
+
+		err := ctx.Next()
+
+		method := ctx.Method()
+
+		route := unmatchedRoute
+		if ctx.Matched() {
+			route = ctx.FullPath()
+		}
+
+		status := ctx.Response().StatusCode()
+		statusClass := strconv.Itoa(status/100) + "xx"
+
+		m.requests.WithLabelValues(method, route, statusClass).Inc()
+		m.duration.WithLabelValues(method, route, statusClass).Observe(time.Since(start).Seconds())
+		m.requestSize.WithLabelValues(method, route).Observe(float64(len(ctx.Request().Body())))
+
+		if size, ok := responseSize(ctx); ok {
+			m.responseSize.WithLabelValues(method, route).Observe(float64(size))
+		}
+
+		if m.errorCodeFunc != nil {
+			if code := m.errorCodeFunc(ctx); code != "" {
+				m.errorCodes.WithLabelValues(method, route, code).Inc()
+			}
+		}
+
+		return err
+	}
+}
+
+// responseSize returns the size of the response body, unknown for a stream without content length.
+func responseSize(ctx fiber.Ctx) (int, bool) {
+	response := ctx.Response()
+	if !response.IsBodyStream() {
+		return len(response.Body()), true
+	}
+
+	if length := response.Header.ContentLength(); length >= 0 {
+		return length, true
+	}
+
+	return 0, false
+}
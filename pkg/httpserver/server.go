@@ -22,9 +22,10 @@ type Config struct {
 	BodyLimit       int           `env:"HTTP_BODY_LIMIT" envDefault:"10485760"` // 10 MB
 	TrustedProxies  []string      `env:"HTTP_TRUSTED_PROXIES" envSeparator:","`
 	AllowedOrigins  []string      `env:"HTTP_ALLOWED_ORIGINS" envSeparator:","`
+	DebugErrors     bool          `env:"HTTP_DEBUG_ERRORS" envDefault:"false"` // expose internal errors, never in production
 }
 
-func New(cfg Config, errorHandler fiber.ErrorHandler) (*fiber.App, error) {
+func New(cfg Config, errorHandler fiber.ErrorHandler, structValidator fiber.StructValidator) (*fiber.App, error) {
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.ShutdownTimeout,
@@ -36,7 +37,8 @@ func New(cfg Config, errorHandler fiber.ErrorHandler) (*fiber.App, error) {
 		TrustProxyConfig: fiber.TrustProxyConfig{
 			Proxies: cfg.TrustedProxies,
 		},
-		ErrorHandler: errorHandler,
+		ErrorHandler:    errorHandler,
+		StructValidator: structValidator,
 	})
 
 	app.Use(recoverMiddleware.New(recoverMiddleware.Config{
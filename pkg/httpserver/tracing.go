@@ -0,0 +1,106 @@
+package httpserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "app/pkg/httpserver"
+
+// Tracing starts a server span for every request, continuing the trace of the W3C trace context of the
+// request. The span is named after the matched route template, carried by the request context and
+// returned in the traceparent response header. Server errors set the span status from the response
+// status, so the middleware must wrap the one answering the errors with the error handler, which finds
+// the span in the request context to record them.
+func Tracing(provider trace.TracerProvider, propagator propagation.TextMapPropagator) fiber.Handler {
+	tracer := provider.Tracer(tracerName)
+
+	return func(ctx fiber.Ctx) error {
+		parent := propagator.Extract(ctx.Context(), requestCarrier{ctx: ctx})
+
+		spanCtx, span := tracer.Start(parent, ctx.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Method()),
+				semconv.URLPath(ctx.Path()),
+				semconv.URLScheme(ctx.Scheme()),
+				semconv.ServerAddress(ctx.Hostname()),
+				semconv.ClientAddress(ctx.IP()),
+				semconv.UserAgentOriginal(ctx.Get(fiber.HeaderUserAgent)),
+				semconv.NetworkProtocolVersion(strings.TrimPrefix(ctx.Protocol(), "HTTP/")),
+			),
+		)
+		defer span.End()
+
+		ctx.SetContext(spanCtx)
+
+		// only the trace context is returned, the baggage is meant for the downstream services
+		propagation.TraceContext{}.Inject(spanCtx, responseCarrier{ctx: ctx})
+
+		err := ctx.Next()
+
+		if ctx.Matched() {
+			route := ctx.FullPath()
+			span.SetName(ctx.Method() + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+
+		status := ctx.Response().StatusCode()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+			span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(status)))
+		}
+
+		return err
+	}
+}
+
+// requestCarrier reads the propagated context from the request headers.
+type requestCarrier struct {
+	ctx fiber.Ctx
+}
+
+func (c requestCarrier) Get(key string) string {
+	return c.ctx.Get(key)
+}
+
+func (c requestCarrier) Set(key, value string) {
+	c.ctx.Request().Header.Set(key, value)
+}
+
+func (c requestCarrier) Keys() []string {
+	headers := c.ctx.GetReqHeaders()
+	keys := make([]string, 0, len(headers))
+
+	for key := range headers {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// responseCarrier writes the propagated context to the response headers.
+type responseCarrier struct {
+	ctx fiber.Ctx
+}
+
+func (c responseCarrier) Get(key string) string {
+	return c.ctx.GetRespHeader(key)
+}
+
+func (c responseCarrier) Set(key, value string) {
+	c.ctx.Set(key, value)
+}
+
+func (c responseCarrier) Keys() []string {
+	return nil
+}
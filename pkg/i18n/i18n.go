@@ -0,0 +1,225 @@
+// Package i18n translates messages keyed by stable identifiers. Translations are loaded
+// from JSON or TOML files named after their locale, e.g. uk.toml or pt-BR.json, whose
+// nested tables are flattened into dotted keys:
+//
+//	[user]
+//	not_found = "користувача не знайдено"
+//	username_too_long = "ім'я користувача задовге, максимум {max} символів"
+//
+// An empty key names the message of the table itself, so validation.min can hold both
+// a message and the more specific validation.min.characters.
+// Placeholders in braces are replaced by the arguments of the same name.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/text/language"
+)
+
+type Config struct {
+	DefaultLocale string `env:"I18N_DEFAULT_LOCALE" envDefault:"en"`
+}
+
+var placeholderPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// Bundle holds the translations of every locale. It is meant to be filled at startup,
+// it is safe for concurrent use once loaded.
+type Bundle struct {
+	defaultTag language.Tag
+	tags       []language.Tag
+	matcher    language.Matcher
+	messages   map[language.Tag]map[string]string
+}
+
+// NewBundle returns a bundle falling back to the default locale.
+func NewBundle(cfg Config) (*Bundle, error) {
+	defaultTag, err := language.Parse(cfg.DefaultLocale)
+	if err != nil {
+		return nil, fmt.Errorf("parse default locale: %w", err)
+	}
+
+	b := &Bundle{
+		defaultTag: defaultTag,
+		messages:   make(map[language.Tag]map[string]string),
+	}
+	b.addTag(defaultTag)
+
+	return b, nil
+}
+
+// AddMessages adds the translations of a locale, overriding the keys already present.
+func (b *Bundle) AddMessages(tag language.Tag, messages map[string]string) {
+	b.addTag(tag)
+
+	for key, message := range messages {
+		b.messages[tag][key] = message
+	}
+}
+
+// LoadFS adds the translation files at the root of fsys. Other files are ignored.
+func (b *Bundle) LoadFS(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return fmt.Errorf("read translations: %w", err)
+	}
+
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+
+		if err := b.loadFile(fsys, entry.Name()); err != nil {
+			return fmt.Errorf("load %s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func (b *Bundle) loadFile(fsys fs.FS, name string) error {
+	ext := path.Ext(name)
+
+	tag, err := language.Parse(strings.TrimSuffix(name, ext))
+	if err != nil {
+		return fmt.Errorf("parse locale: %w", err)
+	}
+
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("read file: %w", err)
+	}
+
+	var raw map[string]any
+
+	switch ext {
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	}
+
+	if err != nil {
+		return fmt.Errorf("decode file: %w", err)
+	}
+
+	messages := make(map[string]string)
+	if err := flatten(raw, "", messages); err != nil {
+		return err
+	}
+
+	b.AddMessages(tag, messages)
+
+	return nil
+}
+
+func (b *Bundle) addTag(tag language.Tag) {
+	if _, ok := b.messages[tag]; ok {
+		return
+	}
+
+	b.messages[tag] = make(map[string]string)
+	b.tags = append(b.tags, tag)
+	b.matcher = language.NewMatcher(b.tags)
+}
+
+// Localizer returns the localizer of the locale best matching the Accept-Language header,
+// or of the default locale when none matches.
+func (b *Bundle) Localizer(acceptLanguage string) *Localizer {
+	tag := b.defaultTag
+
+	if wanted, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil && len(wanted) > 0 {
+		if _, index, confidence := b.matcher.Match(wanted...); confidence != language.No {
+			tag = b.tags[index]
+		}
+	}
+
+	return &Localizer{bundle: b, tag: tag, fallbacks: b.fallbacks(tag)}
+}
+
+// fallbacks lists the locales to look keys up in: the locale itself, its parents, e.g. pt
+// for pt-BR, and the default locale.
+func (b *Bundle) fallbacks(tag language.Tag) []language.Tag {
+	var tags []language.Tag
+
+	for t := tag; t != language.Und; t = t.Parent() {
+		if _, ok := b.messages[t]; ok {
+			tags = append(tags, t)
+		}
+	}
+
+	if tag != b.defaultTag {
+		tags = append(tags, b.defaultTag)
+	}
+
+	return tags
+}
+
+// Localizer translates messages into a locale.
+type Localizer struct {
+	bundle    *Bundle
+	tag       language.Tag
+	fallbacks []language.Tag
+}
+
+// Language returns the locale of the localizer.
+func (l *Localizer) Language() language.Tag {
+	return l.tag
+}
+
+// Translate returns the message of the key with its placeholders filled from args.
+// It reports false when no locale of the fallback chain translates the key.
+func (l *Localizer) Translate(key string, args map[string]any) (string, bool) {
+	for _, tag := range l.fallbacks {
+		if message, ok := l.bundle.messages[tag][key]; ok {
+			return interpolate(message, args), true
+		}
+	}
+
+	return "", false
+}
+
+func interpolate(message string, args map[string]any) string {
+	if len(args) == 0 {
+		return message
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(message, func(placeholder string) string {
+		if val, ok := args[placeholder[1:len(placeholder)-1]]; ok {
+			return fmt.Sprint(val)
+		}
+
+		return placeholder
+	})
+}
+
+func flatten(raw map[string]any, prefix string, messages map[string]string) error {
+	for key, val := range raw {
+		switch {
+		case key == "":
+			key = prefix
+		case prefix != "":
+			key = prefix + "." + key
+		}
+
+		switch val := val.(type) {
+		case string:
+			messages[key] = val
+		case map[string]any:
+			if err := flatten(val, key, messages); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("translation %s is a %T, not a string", key, val)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func newTestBundle(t *testing.T) *Bundle {
+	t.Helper()
+
+	bundle, err := NewBundle(Config{DefaultLocale: "en"})
+	require.NoError(t, err)
+
+	require.NoError(t, bundle.LoadFS(fstest.MapFS{
+		"en.json": {Data: []byte(`{"user": {"not_found": "user not found", "greeting": "hello {name}"}}`)},
+		"uk.toml": {Data: []byte(`
+[user]
+not_found = "користувача не знайдено"
+
+[validation.min]
+"" = "{field} закороткий"
+characters = "{field} має містити {min} символів"
+`)},
+		"pt.toml":    {Data: []byte("\"user.not_found\" = \"usuário não encontrado\"\n")},
+		"pt-BR.json": {Data: []byte(`{"user.greeting": "olá {name}, {missing}"}`)},
+		"README.md":  {Data: []byte("ignored")},
+	}))
+
+	return bundle
+}
+
+func TestBundle_Localizer(t *testing.T) {
+	t.Parallel()
+
+	bundle := newTestBundle(t)
+
+	testCases := []struct {
+		name             string
+		acceptLanguage   string
+		expectedLanguage language.Tag
+	}{
+		{name: "Exact", acceptLanguage: "uk", expectedLanguage: language.Ukrainian},
+		{name: "Region", acceptLanguage: "uk-UA,uk;q=0.9", expectedLanguage: language.Ukrainian},
+		{name: "Quality", acceptLanguage: "fr;q=0.9, pt-BR", expectedLanguage: language.BrazilianPortuguese},
+		{name: "Unsupported", acceptLanguage: "fr", expectedLanguage: language.English},
+		{name: "Missing", acceptLanguage: "", expectedLanguage: language.English},
+		{name: "Malformed", acceptLanguage: "not a;;language", expectedLanguage: language.English},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedLanguage, bundle.Localizer(tc.acceptLanguage).Language())
+		})
+	}
+}
+
+func TestLocalizer_Translate(t *testing.T) {
+	t.Parallel()
+
+	bundle := newTestBundle(t)
+
+	testCases := []struct {
+		name            string
+		acceptLanguage  string
+		key             string
+		args            map[string]any
+		expectedMessage string
+		expectedOK      bool
+	}{
+		{
+			name:            "Nested Key",
+			acceptLanguage:  "uk",
+			key:             "user.not_found",
+			expectedMessage: "користувача не знайдено",
+			expectedOK:      true,
+		},
+		{
+			name:            "Parent Locale",
+			acceptLanguage:  "pt-BR",
+			key:             "user.not_found",
+			expectedMessage: "usuário não encontrado",
+			expectedOK:      true,
+		},
+		{
+			name:            "Default Locale",
+			acceptLanguage:  "uk",
+			key:             "user.greeting",
+			args:            map[string]any{"name": "Ann"},
+			expectedMessage: "hello Ann",
+			expectedOK:      true,
+		},
+		{
+			name:            "Unknown Placeholder",
+			acceptLanguage:  "pt-BR",
+			key:             "user.greeting",
+			args:            map[string]any{"name": "Ann"},
+			expectedMessage: "olá Ann, {missing}",
+			expectedOK:      true,
+		},
+		{
+			name:            "Table Message",
+			acceptLanguage:  "uk",
+			key:             "validation.min",
+			args:            map[string]any{"field": "name"},
+			expectedMessage: "name закороткий",
+			expectedOK:      true,
+		},
+		{
+			name:            "Table Entry",
+			acceptLanguage:  "uk",
+			key:             "validation.min.characters",
+			args:            map[string]any{"field": "name", "min": 3},
+			expectedMessage: "name має містити 3 символів",
+			expectedOK:      true,
+		},
+		{
+			name:           "Unknown Key",
+			acceptLanguage: "uk",
+			key:            "user.unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			message, ok := bundle.Localizer(tc.acceptLanguage).Translate(tc.key, tc.args)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedMessage, message)
+		})
+	}
+}
+
+func TestBundle_LoadFSInvalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		files       fstest.MapFS
+		expectedErr string
+	}{
+		{
+			name:        "Unknown Locale",
+			files:       fstest.MapFS{"translations.json": {Data: []byte(`{}`)}},
+			expectedErr: "parse locale",
+		},
+		{
+			name:        "Malformed File",
+			files:       fstest.MapFS{"uk.toml": {Data: []byte(`[user`)}},
+			expectedErr: "decode file",
+		},
+		{
+			name:        "Not A String",
+			files:       fstest.MapFS{"uk.json": {Data: []byte(`{"user": {"not_found": 1}}`)}},
+			expectedErr: "translation user.not_found is a float64, not a string",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			bundle, err := NewBundle(Config{DefaultLocale: "en"})
+			require.NoError(t, err)
+
+			require.ErrorContains(t, bundle.LoadFS(tc.files), tc.expectedErr)
+		})
+	}
+}
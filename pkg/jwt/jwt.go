@@ -0,0 +1,229 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmHS256 = "HS256"
+
+	hmacMinKeyLength = 32
+)
+
+var (
+	// ErrUnknownAlgorithm is returned when the configured signing algorithm is not supported.
+	ErrUnknownAlgorithm = errors.New("unknown jwt algorithm")
+	// ErrInvalidKey is returned when a configured key cannot be used with the algorithm.
+	ErrInvalidKey = errors.New("invalid jwt key")
+	// ErrUnknownKeyID is returned when the active key id is not among the configured keys.
+	ErrUnknownKeyID = errors.New("unknown jwt key id")
+
+	ErrTokenExpired = jwt.ErrTokenExpired
+	ErrTokenInvalid = errors.New("invalid token")
+)
+
+// Config configures the signing keys. Keys are base64 encoded and keyed by their id (kid):
+// ed25519 seeds for EdDSA or secrets of at least 32 bytes for HS256.
+// Every configured key is accepted for verification, only the active one signs,
+// so keys are rotated by adding a new key, activating it and removing the old one
+// once the tokens it signed have expired.
+type Config struct {
+	Algorithm   string            `env:"JWT_ALGORITHM" envDefault:"EdDSA"`
+	Keys        map[string]string `env:"JWT_KEYS,unset" envKeyValSeparator:":" envSeparator:","`
+	ActiveKeyID string            `env:"JWT_ACTIVE_KEY_ID"`
+	Issuer      string            `env:"JWT_ISSUER" envDefault:"go-hex"`
+	Audience    string            `env:"JWT_AUDIENCE" envDefault:"go-hex"`
+	Leeway      time.Duration     `env:"JWT_LEEWAY" envDefault:"30s"`
+}
+
+type Claims struct {
+	jwt.RegisteredClaims
+
+	Scope string `json:"scope,omitempty"`
+}
+
+type key struct {
+	sign   any
+	verify any
+}
+
+type Manager struct {
+	cfg       Config
+	method    jwt.SigningMethod
+	keys      map[string]key
+	activeKID string
+	parser    *jwt.Parser
+}
+
+func New(cfg Config) (*Manager, error) {
+	method := jwt.GetSigningMethod(cfg.Algorithm)
+	if method == nil || (cfg.Algorithm != AlgorithmEdDSA && cfg.Algorithm != AlgorithmHS256) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+
+	m := &Manager{
+		cfg:       cfg,
+		method:    method,
+		keys:      make(map[string]key, len(cfg.Keys)),
+		activeKID: cfg.ActiveKeyID,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{cfg.Algorithm}),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithLeeway(cfg.Leeway),
+			jwt.WithExpirationRequired(),
+		),
+	}
+
+	for kid, encoded := range cfg.Keys {
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidKey, kid, err)
+		}
+
+		k, err := newKey(cfg.Algorithm, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidKey, kid, err)
+		}
+
+		m.keys[kid] = k
+	}
+
+	if m.activeKID == "" && len(m.keys) == 1 {
+		m.activeKID = slices.Collect(maps.Keys(m.keys))[0]
+	}
+
+	if _, ok := m.keys[m.activeKID]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, m.activeKID)
+	}
+
+	return m, nil
+}
+
+// GenerateKey returns a random base64 encoded key suitable for the algorithm.
+func GenerateKey(algorithm string) (string, error) {
+	size := hmacMinKeyLength
+	if algorithm == AlgorithmEdDSA {
+		size = ed25519.SeedSize
+	}
+
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+func newKey(algorithm string, raw []byte) (key, error) {
+	if algorithm == AlgorithmHS256 {
+		if len(raw) < hmacMinKeyLength {
+			return key{}, fmt.Errorf("secret must be at least %d bytes", hmacMinKeyLength)
+		}
+
+		return key{sign: raw, verify: raw}, nil
+	}
+
+	var private ed25519.PrivateKey
+
+	switch len(raw) {
+	case ed25519.SeedSize:
+		private = ed25519.NewKeyFromSeed(raw)
+	case ed25519.PrivateKeySize:
+		private = ed25519.PrivateKey(raw)
+	default:
+		return key{}, fmt.Errorf("ed25519 key must be a %d byte seed or %d byte private key",
+			ed25519.SeedSize, ed25519.PrivateKeySize)
+	}
+
+	//nolint:forcetypeassert // ed25519.PrivateKey.Public always returns ed25519.PublicKey
+	return key{sign: private, verify: private.Public().(ed25519.PublicKey)}, nil
+}
+
+// Sign fills in the issuer and audience and signs the claims with the active key.
+func (m *Manager) Sign(claims Claims) (string, error) {
+	claims.Issuer = m.cfg.Issuer
+	claims.Audience = jwt.ClaimStrings{m.cfg.Audience}
+
+	token := jwt.NewWithClaims(m.method, claims)
+	token.Header["kid"] = m.activeKID
+
+	signed, err := token.SignedString(m.keys[m.activeKID].sign)
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
+
+	return signed, nil
+}
+
+// Parse verifies the token signature with the key named by its kid header and validates the claims.
+func (m *Manager) Parse(token string) (*Claims, error) {
+	claims := &Claims{}
+
+	_, err := m.parser.ParseWithClaims(token, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		k, ok := m.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, kid)
+		}
+
+		return k.verify, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrTokenInvalid, err)
+	}
+
+	return claims, nil
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public verification keys. Symmetric keys are never published.
+func (m *Manager) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+
+	if m.cfg.Algorithm != AlgorithmEdDSA {
+		return jwks
+	}
+
+	for _, kid := range slices.Sorted(maps.Keys(m.keys)) {
+		public, _ := m.keys[kid].verify.(ed25519.PublicKey)
+
+		jwks.Keys = append(jwks.Keys, JWK{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(public),
+			KeyID:     kid,
+			Algorithm: AlgorithmEdDSA,
+			Use:       "sig",
+		})
+	}
+
+	return jwks
+}
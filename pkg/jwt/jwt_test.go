@@ -0,0 +1,151 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(t *testing.T, algorithm string, kids ...string) Config {
+	t.Helper()
+
+	cfg := Config{
+		Algorithm: algorithm,
+		Keys:      make(map[string]string, len(kids)),
+		Issuer:    "test",
+		Audience:  "test",
+	}
+
+	for _, kid := range kids {
+		key, err := GenerateKey(algorithm)
+		require.NoError(t, err)
+
+		cfg.Keys[kid] = key
+	}
+
+	cfg.ActiveKeyID = kids[len(kids)-1]
+
+	return cfg
+}
+
+func newTestClaims(expiresIn time.Duration) Claims {
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+		Scope: "users:read",
+	}
+}
+
+func TestManager_SignAndParse(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []string{AlgorithmEdDSA, AlgorithmHS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			manager, err := New(newTestConfig(t, algorithm, "k1"))
+			require.NoError(t, err)
+
+			token, err := manager.Sign(newTestClaims(time.Minute))
+			require.NoError(t, err)
+
+			claims, err := manager.Parse(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user", claims.Subject)
+			assert.Equal(t, "users:read", claims.Scope)
+			assert.Equal(t, "test", claims.Issuer)
+		})
+	}
+}
+
+func TestManager_Parse(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTestConfig(t, AlgorithmEdDSA, "k1")
+
+	manager, err := New(cfg)
+	require.NoError(t, err)
+
+	expired, err := manager.Sign(newTestClaims(-time.Minute))
+	require.NoError(t, err)
+
+	_, err = manager.Parse(expired)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+
+	otherAudience := cfg
+	otherAudience.Audience = "other"
+
+	other, err := New(otherAudience)
+	require.NoError(t, err)
+
+	foreign, err := other.Sign(newTestClaims(time.Minute))
+	require.NoError(t, err)
+
+	_, err = manager.Parse(foreign)
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+
+	_, err = manager.Parse("not a token")
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+}
+
+func TestManager_KeyRotation(t *testing.T) {
+	t.Parallel()
+
+	cfg := newTestConfig(t, AlgorithmEdDSA, "old")
+
+	old, err := New(cfg)
+	require.NoError(t, err)
+
+	token, err := old.Sign(newTestClaims(time.Minute))
+	require.NoError(t, err)
+
+	key, err := GenerateKey(AlgorithmEdDSA)
+	require.NoError(t, err)
+
+	cfg.Keys = map[string]string{"old": cfg.Keys["old"], "new": key}
+	cfg.ActiveKeyID = "new"
+
+	rotated, err := New(cfg)
+	require.NoError(t, err)
+
+	_, err = rotated.Parse(token)
+	require.NoError(t, err, "tokens signed with a previous key stay valid")
+
+	delete(cfg.Keys, "old")
+
+	retired, err := New(cfg)
+	require.NoError(t, err)
+
+	_, err = retired.Parse(token)
+	assert.ErrorIs(t, err, ErrTokenInvalid)
+
+	jwks := rotated.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "new", jwks.Keys[0].KeyID)
+	assert.Equal(t, "OKP", jwks.Keys[0].KeyType)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Config{Algorithm: "RS256"})
+	require.ErrorIs(t, err, ErrUnknownAlgorithm)
+
+	_, err = New(Config{Algorithm: AlgorithmHS256, Keys: map[string]string{"k1": "c2hvcnQ="}})
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	cfg := newTestConfig(t, AlgorithmHS256, "k1", "k2")
+	cfg.ActiveKeyID = "k3"
+
+	_, err = New(cfg)
+	require.ErrorIs(t, err, ErrUnknownKeyID)
+
+	hmac, err := New(newTestConfig(t, AlgorithmHS256, "k1"))
+	require.NoError(t, err)
+	assert.Empty(t, hmac.JWKS().Keys)
+}
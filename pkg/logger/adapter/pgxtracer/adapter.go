@@ -4,9 +4,13 @@ import (
 	"context"
 	"time"
 
+	"app/pkg/logger"
+
 	"github.com/rs/zerolog"
 )
 
+const component = "sql"
+
 type Logger struct {
 	maxDuration time.Duration
 }
@@ -18,8 +22,8 @@ func NewAdapter(maxDuration time.Duration) *Logger {
 }
 
 func (l *Logger) Query(ctx context.Context, sql string, duration time.Duration, rowsAffected int64, err error) {
-	entry := zerolog.
-		Ctx(ctx)
+	// the level of the sql component applies, whatever the logger of the context
+	entry := logger.Component(*zerolog.Ctx(ctx), component)
 
 	var event *zerolog.Event
 
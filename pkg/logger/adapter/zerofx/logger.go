@@ -0,0 +1,149 @@
+// Package zerofx provides an fx event logger writing to a zerolog logger, so that the
+// logs of the dependency injection are structured like the other ones.
+package zerofx
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/fx/fxevent"
+)
+
+type Logger struct {
+	logger *zerolog.Logger
+}
+
+var _ fxevent.Logger = (*Logger)(nil)
+
+// NewLogger returns an fx event logger. The wiring of the graph is logged at debug level,
+// the lifecycle of the application at info level and the failures at error level.
+func NewLogger(l *zerolog.Logger) *Logger {
+	return &Logger{
+		logger: l,
+	}
+}
+
+//nolint:cyclop,funlen,gocognit,gocyclo,maintidx // one case per fx event
+func (l *Logger) LogEvent(event fxevent.Event) {
+	switch e := event.(type) {
+	case *fxevent.OnStartExecuting:
+		l.logger.Debug().Str("callee", e.FunctionName).Str("caller", e.CallerName).Msg("OnStart hook executing")
+	case *fxevent.OnStartExecuted:
+		if e.Err != nil {
+			l.logger.Error().Err(e.Err).Str("callee", e.FunctionName).Str("caller", e.CallerName).
+				Msg("OnStart hook failed")
+
+			return
+		}
+
+		l.logger.Debug().Str("callee", e.FunctionName).Str("caller", e.CallerName).Dur("runtime", e.Runtime).
+			Msg("OnStart hook executed")
+	case *fxevent.OnStopExecuting:
+		l.logger.Debug().Str("callee", e.FunctionName).Str("caller", e.CallerName).Msg("OnStop hook executing")
+	case *fxevent.OnStopExecuted:
+		if e.Err != nil {
+			l.logger.Error().Err(e.Err).Str("callee", e.FunctionName).Str("caller", e.CallerName).
+				Msg("OnStop hook failed")
+
+			return
+		}
+
+		l.logger.Debug().Str("callee", e.FunctionName).Str("caller", e.CallerName).Dur("runtime", e.Runtime).
+			Msg("OnStop hook executed")
+	case *fxevent.Supplied:
+		if e.Err != nil {
+			withModule(l.logger.Error(), e.ModuleName).Err(e.Err).Str("type", e.TypeName).Msg("supply failed")
+
+			return
+		}
+
+		withModule(l.logger.Debug(), e.ModuleName).Str("type", e.TypeName).Msg("supplied")
+	case *fxevent.Provided:
+		for _, typeName := range e.OutputTypeNames {
+			withModule(l.logger.Debug(), e.ModuleName).Str("constructor", e.ConstructorName).Str("type", typeName).
+				Bool("private", e.Private).Msg("provided")
+		}
+
+		if e.Err != nil {
+			withModule(l.logger.Error(), e.ModuleName).Err(e.Err).Str("constructor", e.ConstructorName).
+				Msg("provide failed")
+		}
+	case *fxevent.Replaced:
+		for _, typeName := range e.OutputTypeNames {
+			withModule(l.logger.Debug(), e.ModuleName).Str("type", typeName).Msg("replaced")
+		}
+
+		if e.Err != nil {
+			withModule(l.logger.Error(), e.ModuleName).Err(e.Err).Msg("replace failed")
+		}
+	case *fxevent.Decorated:
+		for _, typeName := range e.OutputTypeNames {
+			withModule(l.logger.Debug(), e.ModuleName).Str("decorator", e.DecoratorName).Str("type", typeName).
+				Msg("decorated")
+		}
+
+		if e.Err != nil {
+			withModule(l.logger.Error(), e.ModuleName).Err(e.Err).Str("decorator", e.DecoratorName).
+				Msg("decorate failed")
+		}
+	case *fxevent.BeforeRun:
+		withModule(l.logger.Debug(), e.ModuleName).Str("name", e.Name).Str("kind", e.Kind).Msg("before run")
+	case *fxevent.Run:
+		if e.Err != nil {
+			withModule(l.logger.Error(), e.ModuleName).Err(e.Err).Str("name", e.Name).Str("kind", e.Kind).
+				Msg("run failed")
+
+			return
+		}
+
+		withModule(l.logger.Debug(), e.ModuleName).Str("name", e.Name).Str("kind", e.Kind).
+			Dur("runtime", e.Runtime).Msg("run")
+	case *fxevent.Invoking:
+		withModule(l.logger.Debug(), e.ModuleName).Str("function", e.FunctionName).Msg("invoking")
+	case *fxevent.Invoked:
+		if e.Err != nil {
+			withModule(l.logger.Error(), e.ModuleName).Err(e.Err).Str("function", e.FunctionName).
+				Str("stack", e.Trace).Msg("invoke failed")
+		}
+	case *fxevent.Stopping:
+		l.logger.Info().Str("signal", strings.ToUpper(e.Signal.String())).Msg("received signal")
+	case *fxevent.Stopped:
+		if e.Err != nil {
+			l.logger.Error().Err(e.Err).Msg("stop failed")
+
+			return
+		}
+
+		l.logger.Info().Msg("stopped")
+	case *fxevent.RollingBack:
+		l.logger.Error().Err(e.StartErr).Msg("start failed, rolling back")
+	case *fxevent.RolledBack:
+		if e.Err != nil {
+			l.logger.Error().Err(e.Err).Msg("rollback failed")
+		}
+	case *fxevent.Started:
+		if e.Err != nil {
+			l.logger.Error().Err(e.Err).Msg("start failed")
+
+			return
+		}
+
+		l.logger.Info().Msg("started")
+	case *fxevent.LoggerInitialized:
+		if e.Err != nil {
+			l.logger.Error().Err(e.Err).Msg("fx logger initialization failed")
+
+			return
+		}
+
+		l.logger.Debug().Str("function", e.ConstructorName).Msg("fx logger initialized")
+	}
+}
+
+func withModule(event *zerolog.Event, module string) *zerolog.Event {
+	if module == "" {
+		return event
+	}
+
+	return event.Str("module", module)
+}
@@ -0,0 +1,93 @@
+package zerofx
+
+import (
+	"bytes"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx/fxevent"
+)
+
+func TestLogger_LogEvent(t *testing.T) {
+	t.Parallel()
+
+	errFailed := errors.New("failed")
+
+	testCases := []struct {
+		name     string
+		event    fxevent.Event
+		expected string
+	}{
+		{
+			name:     "provided",
+			event:    &fxevent.Provided{ConstructorName: "NewServer()", OutputTypeNames: []string{"*fiber.App"}},
+			expected: `{"level":"debug","constructor":"NewServer()","type":"*fiber.App","private":false,"message":"provided"}`,
+		},
+		{
+			name:     "provide failed",
+			event:    &fxevent.Provided{ConstructorName: "NewServer()", ModuleName: "http", Err: errFailed},
+			expected: `{"level":"error","module":"http","error":"failed","constructor":"NewServer()","message":"provide failed"}`,
+		},
+		{
+			name:     "invoked",
+			event:    &fxevent.Invoked{FunctionName: "RunMigrations()"},
+			expected: ``,
+		},
+		{
+			name:     "invoke failed",
+			event:    &fxevent.Invoked{FunctionName: "RunMigrations()", Trace: "main.go:1", Err: errFailed},
+			expected: `{"level":"error","error":"failed","function":"RunMigrations()","stack":"main.go:1","message":"invoke failed"}`,
+		},
+		{
+			name: "OnStart hook executed",
+			event: &fxevent.OnStartExecuted{
+				FunctionName: "StartHTTPServer.func1()", CallerName: "StartHTTPServer", Runtime: 15 * time.Millisecond,
+			},
+			expected: `{"level":"debug","callee":"StartHTTPServer.func1()","caller":"StartHTTPServer","runtime":15,` +
+				`"message":"OnStart hook executed"}`,
+		},
+		{
+			name:     "OnStop hook failed",
+			event:    &fxevent.OnStopExecuted{FunctionName: "DrainOnStop.func1()", CallerName: "DrainOnStop", Err: errFailed},
+			expected: `{"level":"error","error":"failed","callee":"DrainOnStop.func1()","caller":"DrainOnStop","message":"OnStop hook failed"}`,
+		},
+		{
+			name:     "stopping",
+			event:    &fxevent.Stopping{Signal: syscall.SIGTERM},
+			expected: `{"level":"info","signal":"TERMINATED","message":"received signal"}`,
+		},
+		{
+			name:     "started",
+			event:    &fxevent.Started{},
+			expected: `{"level":"info","message":"started"}`,
+		},
+		{
+			name:     "rolling back",
+			event:    &fxevent.RollingBack{StartErr: errFailed},
+			expected: `{"level":"error","error":"failed","message":"start failed, rolling back"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			logger := zerolog.New(&buf)
+			NewLogger(&logger).LogEvent(tc.event)
+
+			if tc.expected == "" {
+				assert.Empty(t, buf.String())
+
+				return
+			}
+
+			assert.JSONEq(t, tc.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Package zeroslog provides a log/slog handler writing to a zerolog logger, for the
+// libraries that log through slog.
+package zeroslog
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	logging "app/pkg/logger"
+
+	"github.com/rs/zerolog"
+)
+
+// componentKey is the attribute naming the component of the records, whose level applies.
+const componentKey = "component"
+
+// groupAttrs are attributes added with WithAttrs, under the groups opened at that point.
+type groupAttrs struct {
+	depth int
+	attrs []slog.Attr
+}
+
+type Handler struct {
+	logger    *zerolog.Logger
+	component string
+	groups    []string
+	attrs     []groupAttrs
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// NewHandler returns a handler writing to the logger, or to the logger carried by the
+// context of a record when there is one, so that request scoped fields are kept.
+func NewHandler(logger *zerolog.Logger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// Level maps a slog level to the zerolog level it falls in.
+func Level(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	logger := h.contextLogger(ctx)
+	zerologLevel := Level(level)
+
+	return zerologLevel >= logger.GetLevel() && zerologLevel >= logging.Level(h.component)
+}
+
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	logger := h.contextLogger(ctx)
+	if h.component != "" {
+		component := logging.Component(*logger, h.component)
+		logger = &component
+	}
+
+	event := logger.WithLevel(Level(record.Level))
+	if event == nil {
+		return nil
+	}
+
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+
+		return true
+	})
+
+	h.appendAttrs(event, 0, attrs)
+	event.Msg(record.Message)
+
+	return nil
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	clone := *h
+
+	// the component is tagged by its logger rather than as an attribute
+	if len(h.groups) == 0 {
+		attrs = slices.DeleteFunc(slices.Clone(attrs), func(attr slog.Attr) bool {
+			if attr.Key != componentKey || attr.Value.Kind() != slog.KindString {
+				return false
+			}
+
+			clone.component = attr.Value.String()
+
+			return true
+		})
+	}
+
+	if len(attrs) > 0 {
+		clone.attrs = append(slices.Clip(h.attrs), groupAttrs{depth: len(h.groups), attrs: attrs})
+	}
+
+	return &clone
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.groups = append(slices.Clip(h.groups), name)
+
+	return &clone
+}
+
+func (h *Handler) contextLogger(ctx context.Context) *zerolog.Logger {
+	if ctx == nil {
+		return h.logger
+	}
+
+	logger := zerolog.Ctx(ctx)
+	if logger == zerolog.DefaultContextLogger || logger.GetLevel() == zerolog.Disabled {
+		return h.logger
+	}
+
+	return logger
+}
+
+// appendAttrs adds the attributes of the groups from the depth on, the ones of the record
+// belonging to the innermost group. Groups without attributes are omitted.
+func (h *Handler) appendAttrs(event *zerolog.Event, depth int, record []slog.Attr) {
+	for _, group := range h.attrs {
+		if group.depth == depth {
+			appendAttrs(event, group.attrs)
+		}
+	}
+
+	if depth == len(h.groups) {
+		appendAttrs(event, record)
+
+		return
+	}
+
+	if !h.hasAttrs(depth+1, record) {
+		return
+	}
+
+	dict := zerolog.Dict()
+	h.appendAttrs(dict, depth+1, record)
+	event.Dict(h.groups[depth], dict)
+}
+
+func (h *Handler) hasAttrs(depth int, record []slog.Attr) bool {
+	if len(record) > 0 {
+		return true
+	}
+
+	for _, group := range h.attrs {
+		if group.depth >= depth {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendAttrs(event *zerolog.Event, attrs []slog.Attr) {
+	for _, attr := range attrs {
+		appendAttr(event, attr)
+	}
+}
+
+func appendAttr(event *zerolog.Event, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	value := attr.Value
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		if len(group) == 0 {
+			return
+		}
+
+		// a group without a key is inlined
+		if attr.Key == "" {
+			appendAttrs(event, group)
+
+			return
+		}
+
+		dict := zerolog.Dict()
+		appendAttrs(dict, group)
+		event.Dict(attr.Key, dict)
+	case slog.KindString:
+		event.Str(attr.Key, value.String())
+	case slog.KindInt64:
+		event.Int64(attr.Key, value.Int64())
+	case slog.KindUint64:
+		event.Uint64(attr.Key, value.Uint64())
+	case slog.KindFloat64:
+		event.Float64(attr.Key, value.Float64())
+	case slog.KindBool:
+		event.Bool(attr.Key, value.Bool())
+	case slog.KindDuration:
+		event.Dur(attr.Key, value.Duration())
+	case slog.KindTime:
+		event.Time(attr.Key, value.Time())
+	default:
+		if err, ok := value.Any().(error); ok {
+			event.AnErr(attr.Key, err)
+
+			return
+		}
+
+		event.Interface(attr.Key, value.Any())
+	}
+}
@@ -0,0 +1,129 @@
+package zeroslog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	logging "app/pkg/logger"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_Conformance(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := zerolog.New(&buf).With().Timestamp().Logger()
+
+	err := slogtest.TestHandler(NewHandler(&logger), func() []map[string]any {
+		var results []map[string]any
+
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var result map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &result))
+
+			result[slog.MessageKey] = result[zerolog.MessageFieldName]
+			delete(result, zerolog.MessageFieldName)
+
+			results = append(results, result)
+		}
+
+		return results
+	})
+
+	// the time of the records is the one of the zerolog timestamp
+	for _, err := range unwrapErrors(err) {
+		if !strings.Contains(err.Error(), "zero Record.Time") {
+			t.Error(err)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	var base, request bytes.Buffer
+
+	logger := zerolog.New(&base).Level(zerolog.InfoLevel)
+	requestLogger := zerolog.New(&request).With().Str("request_id", "42").Logger()
+
+	slogger := slog.New(NewHandler(&logger)).With("component", "billing").WithGroup("invoice")
+
+	assert.False(t, slogger.Enabled(t.Context(), slog.LevelDebug))
+	assert.True(t, slogger.Enabled(requestLogger.WithContext(t.Context()), slog.LevelInfo))
+
+	slogger.Warn("overdue", "id", 7, "due", 24*time.Hour, slog.Group("customer", "name", "john"))
+	slogger.ErrorContext(requestLogger.WithContext(context.Background()), "charge failed",
+		"err", assert.AnError, slog.Int("attempt", 3))
+
+	assert.Equal(t,
+		`{"level":"warn","component":"billing","invoice":{"id":7,"due":86400000,"customer":{"name":"john"}},"message":"overdue"}`+"\n",
+		base.String())
+	assert.Equal(t,
+		`{"level":"error","request_id":"42","component":"billing","invoice":{"err":"assert.AnError general error for testing","attempt":3},"message":"charge failed"}`+"\n",
+		request.String())
+}
+
+func TestHandler_ComponentLevel(t *testing.T) {
+	logging.SetLevel("billing", zerolog.DebugLevel, time.Minute)
+	t.Cleanup(func() { logging.ResetLevel("billing") })
+
+	logger := zerolog.New(io.Discard)
+	handler := NewHandler(&logger)
+
+	// the effective level of the component applies, the one of the zerolog logger is trace
+	assert.False(t, handler.Enabled(t.Context(), slog.LevelDebug))
+	assert.True(t, handler.WithAttrs([]slog.Attr{slog.String("component", "billing")}).
+		Enabled(t.Context(), slog.LevelDebug))
+	assert.False(t, handler.WithAttrs([]slog.Attr{slog.String("component", "sql")}).
+		Enabled(t.Context(), slog.LevelDebug))
+}
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		level    slog.Level
+		expected zerolog.Level
+	}{
+		{level: slog.LevelDebug - 1, expected: zerolog.TraceLevel},
+		{level: slog.LevelDebug, expected: zerolog.DebugLevel},
+		{level: slog.LevelInfo, expected: zerolog.InfoLevel},
+		{level: slog.LevelInfo + 2, expected: zerolog.InfoLevel},
+		{level: slog.LevelWarn, expected: zerolog.WarnLevel},
+		{level: slog.LevelError, expected: zerolog.ErrorLevel},
+		{level: slog.LevelError + 4, expected: zerolog.ErrorLevel},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.level.String(), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, Level(tc.level))
+		})
+	}
+}
+
+func unwrapErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+
+	return []error{err}
+}
@@ -0,0 +1,251 @@
+package logger
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const componentField = "component"
+
+// Override is a level set at runtime, reverted to the configured level once it expires.
+// An empty component overrides the level of every logger.
+type Override struct {
+	Component string        `json:"component,omitempty"`
+	Level     zerolog.Level `json:"level"`
+	ExpiresAt time.Time     `json:"expires_at"`
+
+	timer *time.Timer
+}
+
+// levelSnapshot are the effective levels, swapped atomically so that loggers read them lock free.
+type levelSnapshot struct {
+	global     zerolog.Level
+	components map[string]zerolog.Level
+	sampler    zerolog.Sampler
+}
+
+type levelRegistry struct {
+	// apply receives the most verbose effective level whenever the levels change, once configured.
+	apply func(zerolog.Level)
+
+	mu         sync.Mutex
+	configured bool
+	level      zerolog.Level
+	components map[string]zerolog.Level
+	sampler    zerolog.Sampler
+	overrides  map[string]*Override
+	ttl        time.Duration
+	now        func() time.Time
+
+	snapshot atomic.Pointer[levelSnapshot]
+}
+
+// levels keeps the zerolog global level at the most verbose effective level, so that the events
+// below every level are dropped before they are built. It is left untouched until configured.
+var levels = newLevelRegistry(zerolog.SetGlobalLevel)
+
+func newLevelRegistry(apply func(zerolog.Level)) *levelRegistry {
+	registry := &levelRegistry{
+		level:     zerolog.InfoLevel,
+		overrides: make(map[string]*Override),
+		now:       time.Now,
+		apply:     apply,
+	}
+	registry.update()
+
+	return registry
+}
+
+// configure sets the configured levels and sampler, the overrides stay until they expire.
+func (r *levelRegistry) configure(
+	level zerolog.Level, components map[string]zerolog.Level, ttl time.Duration, sampler zerolog.Sampler,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.level, r.components, r.ttl, r.sampler = level, components, ttl, sampler
+	r.configured = true
+	r.update()
+}
+
+func (r *levelRegistry) set(component string, level zerolog.Level, ttl time.Duration) Override {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ttl <= 0 {
+		ttl = r.ttl
+	}
+
+	if previous, ok := r.overrides[component]; ok {
+		previous.timer.Stop()
+	}
+
+	override := &Override{Component: component, Level: level, ExpiresAt: r.now().Add(ttl)}
+	override.timer = time.AfterFunc(ttl, func() {
+		r.expire(override)
+	})
+
+	r.overrides[component] = override
+	r.update()
+
+	return *override
+}
+
+func (r *levelRegistry) reset(component string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if override, ok := r.overrides[component]; ok {
+		override.timer.Stop()
+		delete(r.overrides, component)
+		r.update()
+	}
+}
+
+func (r *levelRegistry) expire(override *Override) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the override may have been replaced since its timer was started
+	if r.overrides[override.Component] == override {
+		delete(r.overrides, override.Component)
+		r.update()
+	}
+}
+
+func (r *levelRegistry) list() []Override {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	overrides := make([]Override, 0, len(r.overrides))
+	for _, component := range slices.Sorted(maps.Keys(r.overrides)) {
+		overrides = append(overrides, *r.overrides[component])
+	}
+
+	return overrides
+}
+
+// update computes the effective levels: a component override first, then the global override,
+// then the configured component level and finally the configured level. It must be called locked.
+func (r *levelRegistry) update() {
+	snapshot := &levelSnapshot{
+		global:     r.level,
+		components: maps.Clone(r.components),
+		sampler:    r.sampler,
+	}
+
+	if snapshot.components == nil {
+		snapshot.components = make(map[string]zerolog.Level)
+	}
+
+	if override, ok := r.overrides[""]; ok {
+		snapshot.global = override.Level
+		for component := range snapshot.components {
+			snapshot.components[component] = override.Level
+		}
+	}
+
+	for component, override := range r.overrides {
+		if component != "" {
+			snapshot.components[component] = override.Level
+		}
+	}
+
+	r.snapshot.Store(snapshot)
+
+	if r.apply != nil && r.configured {
+		r.apply(snapshot.min())
+	}
+}
+
+func (r *levelRegistry) get(component string) zerolog.Level {
+	return r.snapshot.Load().level(component)
+}
+
+func (s *levelSnapshot) level(component string) zerolog.Level {
+	if level, ok := s.components[component]; ok {
+		return level
+	}
+
+	return s.global
+}
+
+// min returns the most verbose of the effective levels.
+func (s *levelSnapshot) min() zerolog.Level {
+	level := s.global
+	for _, component := range s.components {
+		level = min(level, component)
+	}
+
+	return level
+}
+
+// levelHook drops the events below the effective level of the component of their logger.
+type levelHook struct {
+	registry *levelRegistry
+}
+
+func (h levelHook) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	if level != zerolog.Disabled && level < h.registry.get(componentOf(e.GetCtx())) {
+		e.Discard()
+	}
+}
+
+// configuredSampler samples the events with the sampler of the last configuration.
+type configuredSampler struct {
+	registry *levelRegistry
+}
+
+func (s configuredSampler) Sample(level zerolog.Level) bool {
+	sampler := s.registry.snapshot.Load().sampler
+
+	return sampler == nil || sampler.Sample(level)
+}
+
+// SetLevel overrides the level of the component, or of every logger when the component is empty,
+// until the TTL elapses. A TTL of zero uses LOG_LEVEL_OVERRIDE_TTL.
+func SetLevel(component string, level zerolog.Level, ttl time.Duration) Override {
+	return levels.set(component, level, ttl)
+}
+
+// ResetLevel reverts the override of the component to the configured level.
+func ResetLevel(component string) {
+	levels.reset(component)
+}
+
+// Level returns the effective level of the component, or the global one when the component is empty.
+func Level(component string) zerolog.Level {
+	return levels.get(component)
+}
+
+// Overrides returns the levels set at runtime that did not expire yet.
+func Overrides() []Override {
+	return levels.list()
+}
+
+type componentKey struct{}
+
+// Component returns a child logger tagged with the component, filtered by the level of the component.
+// The component is carried by the context of the logger, which hooks read: an event given
+// another context through Event.Ctx is filtered by the global level.
+func Component(logger zerolog.Logger, component string) zerolog.Logger {
+	ctx := context.WithValue(context.Background(), componentKey{}, component)
+
+	return logger.With().Str(componentField, component).Ctx(ctx).Logger()
+}
+
+func componentOf(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	component, _ := ctx.Value(componentKey{}).(string)
+
+	return component
+}
@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelRegistry_Precedence(t *testing.T) {
+	t.Parallel()
+
+	registry := newLevelRegistry(nil)
+	registry.configure(zerolog.InfoLevel, map[string]zerolog.Level{
+		"migrator": zerolog.WarnLevel,
+		"sql":      zerolog.ErrorLevel,
+	}, time.Hour, nil)
+
+	testCases := []struct {
+		name      string
+		overrides map[string]zerolog.Level
+		expected  map[string]zerolog.Level
+	}{
+		{
+			name: "Configured",
+			expected: map[string]zerolog.Level{
+				"": zerolog.InfoLevel, "migrator": zerolog.WarnLevel, "sql": zerolog.ErrorLevel, "other": zerolog.InfoLevel,
+			},
+		},
+		{
+			name:      "Component Override",
+			overrides: map[string]zerolog.Level{"sql": zerolog.DebugLevel},
+			expected: map[string]zerolog.Level{
+				"": zerolog.InfoLevel, "migrator": zerolog.WarnLevel, "sql": zerolog.DebugLevel, "other": zerolog.InfoLevel,
+			},
+		},
+		{
+			name:      "Global Override",
+			overrides: map[string]zerolog.Level{"": zerolog.TraceLevel},
+			expected: map[string]zerolog.Level{
+				"": zerolog.TraceLevel, "migrator": zerolog.TraceLevel, "sql": zerolog.TraceLevel, "other": zerolog.TraceLevel,
+			},
+		},
+		{
+			name:      "Component And Global Override",
+			overrides: map[string]zerolog.Level{"": zerolog.DebugLevel, "sql": zerolog.WarnLevel},
+			expected: map[string]zerolog.Level{
+				"": zerolog.DebugLevel, "migrator": zerolog.DebugLevel, "sql": zerolog.WarnLevel, "other": zerolog.DebugLevel,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		for component, level := range tc.overrides {
+			registry.set(component, level, 0)
+		}
+
+		for component, level := range tc.expected {
+			assert.Equal(t, level, registry.get(component), "%s: level of %q", tc.name, component)
+		}
+
+		for component := range tc.overrides {
+			registry.reset(component)
+		}
+	}
+}
+
+func TestLevelRegistry_Expiry(t *testing.T) {
+	t.Parallel()
+
+	registry := newLevelRegistry(nil)
+	registry.configure(zerolog.InfoLevel, nil, time.Hour, nil)
+
+	override := registry.set("sql", zerolog.DebugLevel, 10*time.Millisecond)
+	assert.Equal(t, zerolog.DebugLevel, registry.get("sql"))
+	assert.Equal(t, []Override{override}, registry.list())
+
+	assert.Eventually(t, func() bool {
+		return registry.get("sql") == zerolog.InfoLevel
+	}, time.Second, 5*time.Millisecond)
+	assert.Empty(t, registry.list())
+}
+
+func TestLevelRegistry_ReplacedOverride(t *testing.T) {
+	t.Parallel()
+
+	registry := newLevelRegistry(nil)
+	registry.configure(zerolog.InfoLevel, nil, time.Hour, nil)
+
+	registry.set("", zerolog.DebugLevel, 10*time.Millisecond)
+	registry.set("", zerolog.TraceLevel, time.Hour)
+
+	time.Sleep(30 * time.Millisecond)
+
+	assert.Equal(t, zerolog.TraceLevel, registry.get(""))
+}
+
+func TestReload(t *testing.T) {
+	t.Parallel()
+
+	err := Reload(Config{LogLevel: zerolog.InfoLevel, ComponentLevels: map[string]string{"sql": "verbose"}})
+	assert.ErrorContains(t, err, "parse level of sql")
+}
+
+// restoreGlobalLevel restores the zerolog global level that Reload sets.
+func restoreGlobalLevel(t *testing.T) {
+	t.Helper()
+
+	level := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(level) })
+}
+
+func TestComponent(t *testing.T) {
+	restoreGlobalLevel(t)
+
+	require.NoError(t, Reload(Config{
+		LogLevel:         zerolog.InfoLevel,
+		ComponentLevels:  map[string]string{"sql": "debug"},
+		LevelOverrideTTL: time.Hour,
+	}))
+	t.Cleanup(func() { ResetLevel("migrator") })
+
+	var buf bytes.Buffer
+
+	root := zerolog.New(&buf).Hook(levelHook{registry: levels})
+	sql := Component(root, "sql")
+	migrator := Component(root, "migrator")
+
+	root.Debug().Msg("root debug")
+	sql.Debug().Msg("sql debug")
+	migrator.Debug().Msg("migrator debug")
+
+	SetLevel("migrator", zerolog.DebugLevel, 0)
+	migrator.Debug().Msg("migrator override")
+
+	assert.Equal(t,
+		`{"level":"debug","component":"sql","message":"sql debug"}`+"\n"+
+			`{"level":"debug","component":"migrator","message":"migrator override"}`+"\n",
+		buf.String())
+}
+
+func TestLevelRegistry_Apply(t *testing.T) {
+	t.Parallel()
+
+	var applied zerolog.Level
+
+	registry := newLevelRegistry(func(level zerolog.Level) { applied = level })
+	registry.configure(zerolog.InfoLevel, map[string]zerolog.Level{"migrator": zerolog.WarnLevel}, time.Hour, nil)
+	assert.Equal(t, zerolog.InfoLevel, applied)
+
+	registry.set("sql", zerolog.TraceLevel, 0)
+	assert.Equal(t, zerolog.TraceLevel, applied)
+
+	registry.reset("sql")
+	assert.Equal(t, zerolog.InfoLevel, applied)
+}
+
+func TestComponent_SamplingDisabled(t *testing.T) {
+	restoreGlobalLevel(t)
+
+	require.NoError(t, Reload(Config{LogLevel: zerolog.InfoLevel, LevelOverrideTTL: time.Hour}))
+
+	zerolog.DisableSampling(true)
+	t.Cleanup(func() { zerolog.DisableSampling(false) })
+
+	var buf bytes.Buffer
+
+	logger, err := newLogger(Config{}, &buf)
+	require.NoError(t, err)
+
+	logger.Debug().Msg("debug")
+	sql := Component(*logger, "sql")
+	sql.Debug().Msg("sql debug")
+	logger.Info().Msg("info")
+
+	assert.NotContains(t, buf.String(), "debug")
+	assert.Contains(t, buf.String(), `"message":"info"`)
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -16,10 +15,43 @@ var ErrUnknownLogFormat = errors.New("unknown log format")
 type Config struct {
 	Format   string        `env:"LOG_FORMAT" envDefault:"json"`
 	LogLevel zerolog.Level `env:"LOG_LEVEL" envDefault:"info"`
+	// Outputs are the targets the events are written to, as target[:format[:level]] with the target
+	// one of stderr, stdout, file or syslog, e.g. LOG_OUTPUTS=stderr:console:debug,file:json:info.
+	// The format defaults to LOG_FORMAT, and an output level only restricts LOG_LEVEL further.
+	Outputs []string `env:"LOG_OUTPUTS" envSeparator:","`
+	File    FileConfig
+	Syslog  SyslogConfig
+	// ComponentLevels are the levels of single components, e.g. LOG_COMPONENT_LEVELS=sql=debug,migrator=warn.
+	ComponentLevels map[string]string `env:"LOG_COMPONENT_LEVELS" envKeyValSeparator:"=" envSeparator:","`
+	// LevelOverrideTTL is how long a level set at runtime lasts when no TTL is given.
+	LevelOverrideTTL time.Duration `env:"LOG_LEVEL_OVERRIDE_TTL" envDefault:"15m"`
+
+	// SamplingEvery keeps one out of N events of a level, e.g. LOG_SAMPLING_EVERY=debug=100,info=10.
+	SamplingEvery map[string]uint32 `env:"LOG_SAMPLING_EVERY" envKeyValSeparator:"=" envSeparator:","`
+	// SamplingBurst events of a sampled level are written per SamplingPeriod before sampling starts.
+	SamplingBurst  uint32        `env:"LOG_SAMPLING_BURST" envDefault:"0"`
+	SamplingPeriod time.Duration `env:"LOG_SAMPLING_PERIOD" envDefault:"1s"`
+	// RateLimit is the number of events of a same message written per RateLimitPeriod, 0 disables it.
+	RateLimit       int           `env:"LOG_RATE_LIMIT" envDefault:"0"`
+	RateLimitPeriod time.Duration `env:"LOG_RATE_LIMIT_PERIOD" envDefault:"1s"`
+	// DedupWindow collapses the repetitions of a message within the window into one event
+	// with their count, 0 disables it.
+	DedupWindow time.Duration `env:"LOG_DEDUP_WINDOW" envDefault:"0s"`
+
+	Redact RedactConfig
 }
 
 func New(cfg Config) (*zerolog.Logger, error) {
-	logger, err := newLogger(cfg)
+	if err := Reload(cfg); err != nil {
+		return nil, err
+	}
+
+	writer, err := newWriter(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("newWriter: %w", err)
+	}
+
+	logger, err := newLogger(cfg, writer)
 	if err != nil {
 		return nil, fmt.Errorf("newLogger: %w", err)
 	}
@@ -29,23 +61,54 @@ func New(cfg Config) (*zerolog.Logger, error) {
 	return logger, nil
 }
 
-func newLogger(cfg Config) (*zerolog.Logger, error) {
-	var writer io.Writer
+// Reload applies the configured levels and sampling, the levels set at runtime stay until they expire.
+func Reload(cfg Config) error {
+	components := make(map[string]zerolog.Level, len(cfg.ComponentLevels))
+
+	for component, value := range cfg.ComponentLevels {
+		level, err := zerolog.ParseLevel(value)
+		if err != nil {
+			return fmt.Errorf("parse level of %s: %w", component, err)
+		}
+
+		components[component] = level
+	}
 
-	switch cfg.Format {
-	case "console":
-		writer = zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
-	case "json":
-		writer = os.Stderr
-	default:
-		return nil, fmt.Errorf("%w: %s", ErrUnknownLogFormat, cfg.Format)
+	sampler, err := newSampler(cfg)
+	if err != nil {
+		return err
 	}
 
-	logger := zerolog.New(writer).
-		Level(cfg.LogLevel).
+	levels.configure(cfg.LogLevel, components, cfg.LevelOverrideTTL, sampler)
+
+	return nil
+}
+
+func newLogger(cfg Config, writer io.Writer) (*zerolog.Logger, error) {
+	writer, err := newRedactWriter(cfg.Redact, writer)
+	if err != nil {
+		return nil, err
+	}
+
+	base := zerolog.New(writer).
 		With().
 		Timestamp().
 		Logger()
 
+	// the level hook comes first, the others skip the events it dropped
+	hooks := []zerolog.Hook{levelHook{registry: levels}}
+
+	if cfg.DedupWindow > 0 {
+		hooks = append(hooks, newDedupHook(cfg.DedupWindow, base))
+	}
+
+	if cfg.RateLimit > 0 {
+		hooks = append(hooks, newRateLimitHook(cfg.RateLimit, cfg.RateLimitPeriod))
+	}
+
+	logger := base.
+		Sample(configuredSampler{registry: levels}).
+		Hook(hooks...)
+
 	return &logger, nil
 }
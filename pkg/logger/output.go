@@ -0,0 +1,162 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// ErrUnknownLogOutput is returned when the target of an output is not recognized.
+var ErrUnknownLogOutput = errors.New("unknown log output")
+
+const (
+	OutputStderr = "stderr"
+	OutputStdout = "stdout"
+	OutputFile   = "file"
+	OutputSyslog = "syslog"
+
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// FileConfig configures the file output, rotated by size. Backups are removed once older
+// than MaxAge, rounded down to days, or beyond MaxBackups.
+type FileConfig struct {
+	Path       string        `env:"LOG_FILE_PATH" envDefault:"logs/app.log"`
+	MaxSizeMB  int           `env:"LOG_FILE_MAX_SIZE_MB" envDefault:"100"`
+	MaxAge     time.Duration `env:"LOG_FILE_MAX_AGE" envDefault:"168h"`
+	MaxBackups int           `env:"LOG_FILE_MAX_BACKUPS" envDefault:"5"`
+	Compress   bool          `env:"LOG_FILE_COMPRESS" envDefault:"true"`
+}
+
+// SyslogConfig configures the syslog output, the local syslog socket when the address is empty.
+type SyslogConfig struct {
+	Network string `env:"LOG_SYSLOG_NETWORK" envDefault:""`
+	Address string `env:"LOG_SYSLOG_ADDRESS" envDefault:""`
+	Tag     string `env:"LOG_SYSLOG_TAG" envDefault:"go-hex"`
+}
+
+// output is a parsed LOG_OUTPUTS entry, target[:format[:level]].
+type output struct {
+	target string
+	format string
+	level  zerolog.Level
+}
+
+func parseOutput(spec, defaultFormat string) (output, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 {
+		return output{}, fmt.Errorf("%w: %s", ErrUnknownLogOutput, spec)
+	}
+
+	out := output{target: parts[0], format: defaultFormat, level: zerolog.TraceLevel}
+
+	if len(parts) > 1 && parts[1] != "" {
+		out.format = parts[1]
+	}
+
+	if len(parts) > 2 {
+		level, err := zerolog.ParseLevel(parts[2])
+		if err != nil {
+			return output{}, fmt.Errorf("parse level of output %s: %w", spec, err)
+		}
+
+		out.level = level
+	}
+
+	return out, nil
+}
+
+// newWriter writes the events to every output, each one filtered by its own level.
+func newWriter(cfg Config) (io.Writer, error) {
+	specs := cfg.Outputs
+	if len(specs) == 0 {
+		specs = []string{OutputStderr}
+	}
+
+	writers := make([]io.Writer, 0, len(specs))
+
+	for _, spec := range specs {
+		out, err := parseOutput(spec, cfg.Format)
+		if err != nil {
+			return nil, err
+		}
+
+		writer, err := newOutputWriter(cfg, out)
+		if err != nil {
+			return nil, err
+		}
+
+		if out.level > zerolog.TraceLevel {
+			writer = &levelFilterWriter{LevelWriter: writer, level: out.level}
+		}
+
+		writers = append(writers, writer)
+	}
+
+	if len(writers) == 1 {
+		return writers[0], nil
+	}
+
+	return zerolog.MultiLevelWriter(writers...), nil
+}
+
+func newOutputWriter(cfg Config, out output) (zerolog.LevelWriter, error) {
+	var dest io.Writer
+
+	switch out.target {
+	case OutputStderr:
+		dest = os.Stderr
+	case OutputStdout:
+		dest = os.Stdout
+	case OutputFile:
+		dest = &lumberjack.Logger{
+			Filename:   cfg.File.Path,
+			MaxSize:    cfg.File.MaxSizeMB,
+			MaxAge:     int(cfg.File.MaxAge / (24 * time.Hour)),
+			MaxBackups: cfg.File.MaxBackups,
+			Compress:   cfg.File.Compress,
+		}
+	case OutputSyslog:
+		if out.format != FormatJSON {
+			return nil, fmt.Errorf("%w: syslog only supports the %s format", ErrUnknownLogFormat, FormatJSON)
+		}
+
+		return newSyslogWriter(cfg.Syslog)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLogOutput, out.target)
+	}
+
+	switch out.format {
+	case FormatConsole:
+		_, colored := dest.(*os.File)
+
+		return zerolog.LevelWriterAdapter{Writer: zerolog.ConsoleWriter{
+			Out: dest, TimeFormat: time.RFC3339, NoColor: !colored,
+		}}, nil
+	case FormatJSON:
+		return zerolog.LevelWriterAdapter{Writer: dest}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLogFormat, out.format)
+	}
+}
+
+// levelFilterWriter drops the events below the level of its output.
+type levelFilterWriter struct {
+	zerolog.LevelWriter
+	level zerolog.Level
+}
+
+func (w *levelFilterWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < w.level {
+		return len(p), nil
+	}
+
+	return w.LevelWriter.WriteLevel(level, p)
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		spec     string
+		expected output
+		errMsg   string
+	}{
+		{
+			name:     "Target",
+			spec:     "stderr",
+			expected: output{target: OutputStderr, format: FormatJSON, level: zerolog.TraceLevel},
+		},
+		{
+			name:     "Format",
+			spec:     "stdout:console",
+			expected: output{target: OutputStdout, format: FormatConsole, level: zerolog.TraceLevel},
+		},
+		{
+			name:     "Level",
+			spec:     "file::warn",
+			expected: output{target: OutputFile, format: FormatJSON, level: zerolog.WarnLevel},
+		},
+		{name: "Unknown Level", spec: "file:json:verbose", errMsg: "parse level of output file:json:verbose"},
+		{name: "Too Many Parts", spec: "file:json:info:extra", errMsg: "unknown log output: file:json:info:extra"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := parseOutput(tc.spec, FormatJSON)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	jsonPath, consolePath := filepath.Join(dir, "app.log"), filepath.Join(dir, "console.log")
+
+	jsonWriter, err := newWriter(Config{
+		Format:  FormatJSON,
+		Outputs: []string{"file::warn"},
+		File:    FileConfig{Path: jsonPath, MaxSizeMB: 1},
+	})
+	require.NoError(t, err)
+
+	consoleWriter, err := newWriter(Config{
+		Outputs: []string{"file:console"},
+		File:    FileConfig{Path: consolePath, MaxSizeMB: 1},
+	})
+	require.NoError(t, err)
+
+	logger := zerolog.New(zerolog.MultiLevelWriter(jsonWriter, consoleWriter))
+	logger.Info().Msg("started")
+	logger.Error().Msg("connection refused")
+
+	jsonLog, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, `{"level":"error","message":"connection refused"}`+"\n", string(jsonLog))
+
+	consoleLog, err := os.ReadFile(consolePath)
+	require.NoError(t, err)
+	assert.Equal(t, "<nil> INF started\n<nil> ERR connection refused\n", string(consoleLog))
+}
+
+func TestNewWriter_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		cfg    Config
+		errMsg string
+	}{
+		{
+			name:   "Unknown Target",
+			cfg:    Config{Format: FormatJSON, Outputs: []string{"kafka"}},
+			errMsg: "unknown log output: kafka",
+		},
+		{
+			name:   "Unknown Format",
+			cfg:    Config{Format: "xml"},
+			errMsg: "unknown log format: xml",
+		},
+		{
+			name:   "Console Syslog",
+			cfg:    Config{Outputs: []string{"syslog:console"}},
+			errMsg: "unknown log format: syslog only supports the json format",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newWriter(tc.cfg)
+			assert.EqualError(t, err, tc.errMsg)
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	// ErrUnknownRedactDetector is returned when the provided value detector is not recognized.
+	ErrUnknownRedactDetector = errors.New("unknown redaction detector")
+	// ErrUnknownRedactMode is returned when the provided redaction mode is not recognized.
+	ErrUnknownRedactMode = errors.New("unknown redaction mode")
+	// ErrRedactHashKeyRequired is returned for the hash mode without key, whose unkeyed hashes
+	// of low entropy values are reversed by a dictionary.
+	ErrRedactHashKeyRequired = errors.New("redaction hash key is required")
+)
+
+const (
+	DetectorEmail = "email"
+	DetectorToken = "token"
+	DetectorCard  = "card"
+	DetectorIP    = "ip"
+
+	RedactModeMask = "mask"
+	RedactModeHash = "hash"
+
+	redactedMask = "[REDACTED]"
+)
+
+// RedactConfig configures the redaction of the events before they are written to any output.
+type RedactConfig struct {
+	// Fields are the patterns of the field names whose whole value is redacted, matched case-insensitively
+	// at any depth, e.g. LOG_REDACT_FIELDS=password,*token*,ip,args.
+	Fields []string `env:"LOG_REDACT_FIELDS" envSeparator:","`
+	// Detectors find the sensitive values inside the strings of any field, message included,
+	// among email, token, card and ip (IPv4).
+	Detectors []string `env:"LOG_REDACT_DETECTORS" envSeparator:","`
+	// Patterns are additional regular expressions of values to redact.
+	Patterns []string `env:"LOG_REDACT_PATTERNS" envSeparator:","`
+	// Mode is mask to replace the values with [REDACTED], or hash to replace them with a keyed hash
+	// so that they can still be correlated across events, which requires HashKey.
+	Mode    string `env:"LOG_REDACT_MODE" envDefault:"mask"`
+	HashKey string `env:"LOG_REDACT_HASH_KEY,unset"`
+}
+
+func (c RedactConfig) enabled() bool {
+	return len(c.Fields) > 0 || len(c.Detectors) > 0 || len(c.Patterns) > 0
+}
+
+var detectors = map[string]detector{
+	DetectorEmail: {pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	DetectorToken: {pattern: regexp.MustCompile(
+		`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*` + // JWT
+			`|gohex_[0-9a-f]+\.[A-Za-z0-9_-]+` + // API key
+			`|(?i:\b(?:bearer|apikey)\s+)[A-Za-z0-9._~+/=-]+`,
+	)},
+	DetectorCard: {pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`), valid: luhn},
+	DetectorIP: {pattern: regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`), valid: func(value string) bool {
+		_, err := netip.ParseAddr(value)
+
+		return err == nil
+	}},
+}
+
+// detector finds the values matching its pattern, the valid ones only when it has a validation.
+type detector struct {
+	pattern *regexp.Regexp
+	valid   func(value string) bool
+}
+
+type redactor struct {
+	fields    []string
+	detectors []detector
+	hashKey   []byte
+	hash      bool
+}
+
+func newRedactor(cfg RedactConfig) (*redactor, error) {
+	r := &redactor{hashKey: []byte(cfg.HashKey)}
+
+	switch cfg.Mode {
+	case RedactModeMask, "":
+	case RedactModeHash:
+		if cfg.HashKey == "" {
+			return nil, ErrRedactHashKeyRequired
+		}
+
+		r.hash = true
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRedactMode, cfg.Mode)
+	}
+
+	for _, field := range cfg.Fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if _, err := path.Match(field, ""); err != nil {
+			return nil, fmt.Errorf("field pattern %s: %w", field, err)
+		}
+
+		r.fields = append(r.fields, field)
+	}
+
+	for _, name := range cfg.Detectors {
+		d, ok := detectors[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownRedactDetector, name)
+		}
+
+		r.detectors = append(r.detectors, d)
+	}
+
+	for _, expr := range cfg.Patterns {
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("redaction pattern %s: %w", expr, err)
+		}
+
+		r.detectors = append(r.detectors, detector{pattern: pattern})
+	}
+
+	return r, nil
+}
+
+func (r *redactor) matchField(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, field := range r.fields {
+		if ok, _ := path.Match(field, key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *redactor) replacement(value string) string {
+	if !r.hash {
+		return redactedMask
+	}
+
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+
+	return "sha256:" + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// scrub redacts the values found by the detectors inside the string.
+func (r *redactor) scrub(value string) string {
+	for _, d := range r.detectors {
+		value = d.pattern.ReplaceAllStringFunc(value, func(match string) string {
+			if d.valid != nil && !d.valid(match) {
+				return match
+			}
+
+			return r.replacement(match)
+		})
+	}
+
+	return value
+}
+
+// redact rewrites a JSON event, keeping the order of its fields.
+func (r *redactor) redact(p []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	var buf bytes.Buffer
+
+	buf.Grow(len(p))
+
+	if err := r.value(decoder, &buf); err != nil {
+		return nil, err
+	}
+
+	// keep what follows the event, the trailing newline
+	buf.Write(p[decoder.InputOffset():])
+
+	return buf.Bytes(), nil
+}
+
+func (r *redactor) value(decoder *json.Decoder, buf *bytes.Buffer) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		return r.container(decoder, buf, token)
+	case string:
+		return writeJSON(buf, r.scrub(token))
+	default:
+		return writeJSON(buf, token)
+	}
+}
+
+func (r *redactor) container(decoder *json.Decoder, buf *bytes.Buffer, delim json.Delim) error {
+	isObject := delim == '{'
+
+	buf.WriteString(delim.String())
+
+	for i := 0; decoder.More(); i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		if !isObject {
+			if err := r.value(decoder, buf); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := r.field(decoder, buf); err != nil {
+			return err
+		}
+	}
+
+	end, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString(end.(json.Delim).String()) //nolint:forcetypeassert // the decoder fails on unbalanced delimiters
+
+	return nil
+}
+
+func (r *redactor) field(decoder *json.Decoder, buf *bytes.Buffer) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	key, _ := token.(string)
+	if err := writeJSON(buf, key); err != nil {
+		return err
+	}
+
+	buf.WriteByte(':')
+
+	if !r.matchField(key) {
+		return r.value(decoder, buf)
+	}
+
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+
+	value := string(raw)
+
+	var str string
+	if json.Unmarshal(raw, &str) == nil {
+		value = str
+	}
+
+	return writeJSON(buf, r.replacement(value))
+}
+
+// writeJSON encodes the value without escaping HTML, as zerolog does.
+func writeJSON(buf *bytes.Buffer, value any) error {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+
+	// drop the newline of the encoder
+	buf.Truncate(buf.Len() - 1)
+
+	return nil
+}
+
+// luhn validates the check digit of a card number.
+func luhn(value string) bool {
+	var sum, count int
+
+	for i := len(value) - 1; i >= 0; i-- {
+		if value[i] < '0' || value[i] > '9' {
+			continue
+		}
+
+		digit := int(value[i] - '0')
+		if count%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		count++
+	}
+
+	return sum%10 == 0
+}
+
+// redactWriter redacts the events before writing them to the outputs.
+type redactWriter struct {
+	redactor *redactor
+	writer   zerolog.LevelWriter
+}
+
+func newRedactWriter(cfg RedactConfig, writer io.Writer) (io.Writer, error) {
+	if !cfg.enabled() {
+		return writer, nil
+	}
+
+	r, err := newRedactor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	levelWriter, ok := writer.(zerolog.LevelWriter)
+	if !ok {
+		levelWriter = zerolog.LevelWriterAdapter{Writer: writer}
+	}
+
+	return &redactWriter{redactor: r, writer: levelWriter}, nil
+}
+
+func (w *redactWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *redactWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	redacted, err := w.redactor.redact(p)
+	if err != nil {
+		// a line that is not a JSON event, e.g. written by another library, is passed through as is
+		redacted = p
+	}
+
+	if _, err := w.writer.WriteLevel(level, redacted); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
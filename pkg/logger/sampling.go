@@ -0,0 +1,226 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const repeatedField = "repeated"
+
+// newSampler lets LOG_SAMPLING_BURST events of each sampled level pass per period,
+// then one out of the configured number of events.
+func newSampler(cfg Config) (zerolog.Sampler, error) {
+	if len(cfg.SamplingEvery) == 0 {
+		return nil, nil //nolint:nilnil // no sampling
+	}
+
+	var sampler zerolog.LevelSampler
+
+	for name, every := range cfg.SamplingEvery {
+		level, err := zerolog.ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("parse sampled level %s: %w", name, err)
+		}
+
+		levelSampler := zerolog.Sampler(&zerolog.BasicSampler{N: every})
+		if cfg.SamplingBurst > 0 {
+			levelSampler = &zerolog.BurstSampler{
+				Burst:       cfg.SamplingBurst,
+				Period:      cfg.SamplingPeriod,
+				NextSampler: levelSampler,
+			}
+		}
+
+		switch level {
+		case zerolog.TraceLevel:
+			sampler.TraceSampler = levelSampler
+		case zerolog.DebugLevel:
+			sampler.DebugSampler = levelSampler
+		case zerolog.InfoLevel:
+			sampler.InfoSampler = levelSampler
+		case zerolog.WarnLevel:
+			sampler.WarnSampler = levelSampler
+		case zerolog.ErrorLevel:
+			sampler.ErrorSampler = levelSampler
+		default:
+			return nil, fmt.Errorf("level %s cannot be sampled", name)
+		}
+	}
+
+	return sampler, nil
+}
+
+type messageKey struct {
+	level     zerolog.Level
+	message   string
+	component string
+}
+
+func newMessageKey(e *zerolog.Event, level zerolog.Level, message string) messageKey {
+	return messageKey{level: level, message: message, component: componentOf(e.GetCtx())}
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// rateLimitHook drops the events of a message beyond the limit per period.
+// Messages are told apart by level, text and component, fields are not compared.
+type rateLimitHook struct {
+	limit  int
+	period time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	windows   map[messageKey]*rateWindow
+	lastSweep time.Time
+}
+
+func newRateLimitHook(limit int, period time.Duration) *rateLimitHook {
+	return &rateLimitHook{
+		limit:   limit,
+		period:  period,
+		now:     time.Now,
+		windows: make(map[messageKey]*rateWindow),
+	}
+}
+
+func (h *rateLimitHook) Run(e *zerolog.Event, level zerolog.Level, message string) {
+	if level == zerolog.Disabled {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	h.sweep(now)
+
+	key := newMessageKey(e, level, message)
+
+	window, ok := h.windows[key]
+	if !ok || now.Sub(window.start) >= h.period {
+		window = &rateWindow{start: now}
+		h.windows[key] = window
+	}
+
+	window.count++
+	if window.count > h.limit {
+		e.Discard()
+	}
+}
+
+// sweep forgets the elapsed windows once per period, bounding the memory to the recent messages.
+func (h *rateLimitHook) sweep(now time.Time) {
+	if now.Sub(h.lastSweep) < h.period {
+		return
+	}
+
+	for key, window := range h.windows {
+		if now.Sub(window.start) >= h.period {
+			delete(h.windows, key)
+		}
+	}
+
+	h.lastSweep = now
+}
+
+type dedupEntry struct {
+	start    time.Time
+	repeated int
+}
+
+// dedupHook writes the first event of a message and drops its repetitions within the window,
+// which are then reported by a single event with their count. Messages are told apart like
+// by the rate limit, and a single timer sweeps the elapsed windows.
+type dedupHook struct {
+	window time.Duration
+	// logger writes the summaries, it has no hooks so that they are not deduplicated themselves.
+	logger zerolog.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	entries  map[messageKey]*dedupEntry
+	sweeping bool
+}
+
+func newDedupHook(window time.Duration, logger zerolog.Logger) *dedupHook {
+	return &dedupHook{
+		window:  window,
+		logger:  logger,
+		now:     time.Now,
+		entries: make(map[messageKey]*dedupEntry),
+	}
+}
+
+func (h *dedupHook) Run(e *zerolog.Event, level zerolog.Level, message string) {
+	if level == zerolog.Disabled {
+		return
+	}
+
+	key := newMessageKey(e, level, message)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if entry, ok := h.entries[key]; ok {
+		entry.repeated++
+		e.Discard()
+
+		return
+	}
+
+	h.entries[key] = &dedupEntry{start: h.now()}
+
+	if !h.sweeping {
+		h.sweeping = true
+		time.AfterFunc(h.window, h.sweep)
+	}
+}
+
+// sweep reports the repetitions of the elapsed windows, then waits for the next window to elapse.
+func (h *dedupHook) sweep() {
+	h.mu.Lock()
+
+	now := h.now()
+	summaries := make(map[messageKey]int)
+
+	var next time.Time
+
+	for key, entry := range h.entries {
+		end := entry.start.Add(h.window)
+		if !now.Before(end) {
+			delete(h.entries, key)
+
+			if entry.repeated > 0 {
+				summaries[key] = entry.repeated
+			}
+
+			continue
+		}
+
+		if next.IsZero() || end.Before(next) {
+			next = end
+		}
+	}
+
+	h.sweeping = !next.IsZero()
+	if h.sweeping {
+		time.AfterFunc(next.Sub(now), h.sweep)
+	}
+
+	h.mu.Unlock()
+
+	for key, repeated := range summaries {
+		logger := h.logger
+		if key.component != "" {
+			logger = Component(logger, key.component)
+		}
+
+		logger.WithLevel(key.level).Int(repeatedField, repeated).Msg(key.message)
+	}
+}
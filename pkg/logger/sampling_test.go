@@ -0,0 +1,212 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is written by the dedup timers concurrently with the test.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestConfig_Sampling(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := env.ParseAsWithOptions[Config](env.Options{Environment: map[string]string{
+		"LOG_SAMPLING_EVERY": "debug=100,info=10",
+		"LOG_SAMPLING_BURST": "5",
+		"LOG_RATE_LIMIT":     "20",
+		"LOG_DEDUP_WINDOW":   "10s",
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]uint32{"debug": 100, "info": 10}, cfg.SamplingEvery)
+	assert.Equal(t, uint32(5), cfg.SamplingBurst)
+	assert.Equal(t, time.Second, cfg.SamplingPeriod)
+	assert.Equal(t, 20, cfg.RateLimit)
+	assert.Equal(t, 10*time.Second, cfg.DedupWindow)
+}
+
+func TestNewSampler(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cfg      Config
+		expected map[zerolog.Level]int
+		errMsg   string
+	}{
+		{
+			name:     "Disabled",
+			cfg:      Config{},
+			expected: nil,
+		},
+		{
+			name:     "Every",
+			cfg:      Config{SamplingEvery: map[string]uint32{"debug": 10}},
+			expected: map[zerolog.Level]int{zerolog.DebugLevel: 10, zerolog.InfoLevel: 100},
+		},
+		{
+			name: "Burst",
+			cfg: Config{
+				SamplingEvery:  map[string]uint32{"info": 10},
+				SamplingBurst:  20,
+				SamplingPeriod: time.Hour,
+			},
+			expected: map[zerolog.Level]int{zerolog.DebugLevel: 100, zerolog.InfoLevel: 28},
+		},
+		{
+			name:   "Unknown Level",
+			cfg:    Config{SamplingEvery: map[string]uint32{"verbose": 10}},
+			errMsg: "parse sampled level verbose",
+		},
+		{
+			name:   "Unsampled Level",
+			cfg:    Config{SamplingEvery: map[string]uint32{"fatal": 10}},
+			errMsg: "level fatal cannot be sampled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sampler, err := newSampler(tc.cfg)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tc.expected == nil {
+				assert.Nil(t, sampler)
+
+				return
+			}
+
+			for level, expected := range tc.expected {
+				sampled := 0
+
+				for range 100 {
+					if sampler.Sample(level) {
+						sampled++
+					}
+				}
+
+				assert.Equal(t, expected, sampled, "events of level %s", level)
+			}
+		})
+	}
+}
+
+func TestRateLimitHook(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	hook := newRateLimitHook(2, time.Second)
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	hook.now = func() time.Time { return now }
+
+	logger := zerolog.New(&buf).Hook(hook)
+
+	for range 3 {
+		logger.Error().Msg("connection refused")
+		logger.Warn().Msg("connection refused")
+	}
+
+	now = now.Add(time.Second)
+	logger.Error().Msg("connection refused")
+
+	assert.Equal(t, 3, strings.Count(buf.String(), `"level":"error"`))
+	assert.Equal(t, 2, strings.Count(buf.String(), `"level":"warn"`))
+	// the elapsed windows are swept
+	assert.Len(t, hook.windows, 1)
+}
+
+func TestDedupHook(t *testing.T) {
+	t.Parallel()
+
+	var buf syncBuffer
+
+	base := zerolog.New(&buf)
+	logger := base.Hook(newDedupHook(20*time.Millisecond, base))
+
+	for range 3 {
+		logger.Error().Str("user", "john").Msg("connection refused")
+	}
+
+	logger.Info().Msg("started")
+
+	assert.Equal(t,
+		`{"level":"error","user":"john","message":"connection refused"}`+"\n"+
+			`{"level":"info","message":"started"}`+"\n",
+		buf.String())
+
+	assert.Eventually(t, func() bool {
+		return strings.HasSuffix(buf.String(), `{"level":"error","repeated":2,"message":"connection refused"}`+"\n")
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestDedupHook_Component(t *testing.T) {
+	t.Parallel()
+
+	var buf syncBuffer
+
+	base := zerolog.New(&buf)
+	hook := newDedupHook(20*time.Millisecond, base)
+	logger := base.Hook(hook)
+	sql := Component(logger, "sql")
+
+	for range 2 {
+		logger.Warn().Msg("slow")
+		sql.Warn().Msg("slow")
+		sql.Error().Msg("slow")
+	}
+
+	assert.Equal(t,
+		`{"level":"warn","message":"slow"}`+"\n"+
+			`{"level":"warn","component":"sql","message":"slow"}`+"\n"+
+			`{"level":"error","component":"sql","message":"slow"}`+"\n",
+		buf.String())
+
+	assert.Eventually(t, func() bool {
+		return strings.Count(buf.String(), `"repeated":1`) == 3
+	}, time.Second, 5*time.Millisecond)
+	assert.Contains(t, buf.String(), `{"level":"warn","component":"sql","repeated":1,"message":"slow"}`)
+
+	// the sweeper stops once every window elapsed
+	assert.Eventually(t, func() bool {
+		hook.mu.Lock()
+		defer hook.mu.Unlock()
+
+		return len(hook.entries) == 0 && !hook.sweeping
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,207 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// NewWithHandler returns a logger writing its events to a slog handler instead of the
+// configured outputs. Levels, sampling, rate limiting and deduplication stay the same.
+func NewWithHandler(cfg Config, handler slog.Handler) (*zerolog.Logger, error) {
+	if err := Reload(cfg); err != nil {
+		return nil, err
+	}
+
+	logger, err := newLogger(cfg, &slogWriter{handler: handler})
+	if err != nil {
+		return nil, fmt.Errorf("newLogger: %w", err)
+	}
+
+	zerolog.DefaultContextLogger = logger
+
+	return logger, nil
+}
+
+// SlogLevel maps a zerolog level to a slog one, trace being below debug and fatal and panic
+// above error.
+func SlogLevel(level zerolog.Level) slog.Level {
+	switch level {
+	case zerolog.TraceLevel:
+		return slog.LevelDebug - 4
+	case zerolog.DebugLevel:
+		return slog.LevelDebug
+	case zerolog.WarnLevel:
+		return slog.LevelWarn
+	case zerolog.ErrorLevel:
+		return slog.LevelError
+	case zerolog.FatalLevel:
+		return slog.LevelError + 4
+	case zerolog.PanicLevel:
+		return slog.LevelError + 8
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// slogWriter decodes the JSON events of zerolog into slog records, keeping the order of the
+// fields and turning the nested objects into groups.
+type slogWriter struct {
+	handler slog.Handler
+}
+
+func (w *slogWriter) Write(p []byte) (int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		return 0, err
+	}
+
+	var (
+		record = slog.Record{Level: slog.LevelInfo}
+		attrs  []slog.Attr
+	)
+
+	for decoder.More() {
+		key, value, err := decodeField(decoder)
+		if err != nil {
+			return 0, err
+		}
+
+		switch key {
+		case zerolog.LevelFieldName:
+			if level, err := zerolog.ParseLevel(value.String()); err == nil {
+				record.Level = SlogLevel(level)
+			}
+		case zerolog.MessageFieldName:
+			record.Message = value.String()
+		case zerolog.TimestampFieldName:
+			if value.Kind() == slog.KindString {
+				record.Time, _ = time.Parse(zerolog.TimeFieldFormat, value.String())
+			}
+		default:
+			attrs = append(attrs, slog.Attr{Key: key, Value: value})
+		}
+	}
+
+	ctx := context.Background()
+	if !w.handler.Enabled(ctx, record.Level) {
+		return len(p), nil
+	}
+
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+
+	record.AddAttrs(attrs...)
+
+	if err := w.handler.Handle(ctx, record); err != nil {
+		return 0, fmt.Errorf("handle record: %w", err)
+	}
+
+	return len(p), nil
+}
+
+var errUnexpectedToken = errors.New("unexpected token")
+
+func decodeField(decoder *json.Decoder) (string, slog.Value, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return "", slog.Value{}, fmt.Errorf("decode key: %w", err)
+	}
+
+	key, ok := token.(string)
+	if !ok {
+		return "", slog.Value{}, fmt.Errorf("%w: %v", errUnexpectedToken, token)
+	}
+
+	value, err := decodeValue(decoder)
+	if err != nil {
+		return "", slog.Value{}, fmt.Errorf("decode %s: %w", key, err)
+	}
+
+	return key, value, nil
+}
+
+func decodeValue(decoder *json.Decoder) (slog.Value, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return slog.Value{}, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '[' {
+			return decodeArray(decoder)
+		}
+
+		if token != '{' {
+			return slog.Value{}, fmt.Errorf("%w: %v", errUnexpectedToken, token)
+		}
+
+		var attrs []slog.Attr
+
+		for decoder.More() {
+			key, value, err := decodeField(decoder)
+			if err != nil {
+				return slog.Value{}, err
+			}
+
+			attrs = append(attrs, slog.Attr{Key: key, Value: value})
+		}
+
+		return slog.GroupValue(attrs...), expectDelim(decoder, '}')
+	case json.Number:
+		if i, err := token.Int64(); err == nil {
+			return slog.Int64Value(i), nil
+		}
+
+		f, err := token.Float64()
+		if err != nil {
+			return slog.Value{}, err
+		}
+
+		return slog.Float64Value(f), nil
+	case string:
+		return slog.StringValue(token), nil
+	case bool:
+		return slog.BoolValue(token), nil
+	default:
+		return slog.AnyValue(nil), nil
+	}
+}
+
+func decodeArray(decoder *json.Decoder) (slog.Value, error) {
+	var values []any
+
+	for decoder.More() {
+		value, err := decodeValue(decoder)
+		if err != nil {
+			return slog.Value{}, err
+		}
+
+		values = append(values, value.Any())
+	}
+
+	return slog.AnyValue(values), expectDelim(decoder, ']')
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("%w: %v", errUnexpectedToken, token)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogWriter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		level    slog.Level
+		log      func(logger zerolog.Logger)
+		expected string
+	}{
+		{
+			name: "fields in order",
+			log: func(logger zerolog.Logger) {
+				logger.Warn().Str("user", "john").Int("attempt", 3).Float64("ratio", 0.5).Bool("locked", true).
+					Msg("login failed")
+			},
+			expected: `level=WARN msg="login failed" user=john attempt=3 ratio=0.5 locked=true` + "\n",
+		},
+		{
+			name: "nested objects as groups",
+			log: func(logger zerolog.Logger) {
+				logger.Info().Dict("request", zerolog.Dict().Str("method", "GET").Ints("codes", []int{1, 2})).
+					Msg("served")
+			},
+			expected: `level=INFO msg=served request.method=GET request.codes="[1 2]"` + "\n",
+		},
+		{
+			name:  "trace level",
+			level: slog.LevelDebug - 4,
+			log: func(logger zerolog.Logger) {
+				logger.Trace().Msg("query")
+			},
+			expected: `level=DEBUG-4 msg=query` + "\n",
+		},
+		{
+			name:  "disabled level of the handler",
+			level: slog.LevelInfo,
+			log: func(logger zerolog.Logger) {
+				logger.Debug().Msg("skipped")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				Level: tc.level,
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					if len(groups) == 0 && attr.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+
+					return attr
+				},
+			})
+
+			tc.log(zerolog.New(&slogWriter{handler: handler}).With().Timestamp().Logger())
+
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestSlogWriter_Time(t *testing.T) {
+	t.Parallel()
+
+	var record slog.Record
+
+	handler := recordHandler(func(r slog.Record) { record = r })
+
+	_, err := (&slogWriter{handler: handler}).Write([]byte(`{"level":"error","time":"2026-01-02T03:04:05Z","message":"boom"}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, slog.LevelError, record.Level)
+	assert.Equal(t, "boom", record.Message)
+	assert.Equal(t, "2026-01-02T03:04:05Z", record.Time.UTC().Format(zerolog.TimeFieldFormat))
+
+	_, err = (&slogWriter{handler: handler}).Write([]byte(`["not an object"]`))
+	require.ErrorIs(t, err, errUnexpectedToken)
+}
+
+type recordHandler func(slog.Record)
+
+func (h recordHandler) Enabled(_ context.Context, _ slog.Level) bool { return true }
+
+func (h recordHandler) Handle(_ context.Context, record slog.Record) error {
+	h(record)
+
+	return nil
+}
+
+func (h recordHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h recordHandler) WithGroup(string) slog.Handler { return h }
+
+func TestSlogLevel(t *testing.T) {
+	t.Parallel()
+
+	for level, expected := range map[zerolog.Level]slog.Level{
+		zerolog.TraceLevel: slog.LevelDebug - 4,
+		zerolog.DebugLevel: slog.LevelDebug,
+		zerolog.InfoLevel:  slog.LevelInfo,
+		zerolog.WarnLevel:  slog.LevelWarn,
+		zerolog.ErrorLevel: slog.LevelError,
+		zerolog.FatalLevel: slog.LevelError + 4,
+		zerolog.PanicLevel: slog.LevelError + 8,
+		zerolog.NoLevel:    slog.LevelInfo,
+	} {
+		assert.Equal(t, expected, SlogLevel(level), level.String())
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+
+	"github.com/rs/zerolog"
+)
+
+func newSyslogWriter(cfg SyslogConfig) (zerolog.LevelWriter, error) {
+	writer, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_DAEMON, cfg.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("dial syslog: %w", err)
+	}
+
+	return zerolog.SyslogLevelWriter(writer), nil
+}
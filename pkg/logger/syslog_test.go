@@ -0,0 +1,40 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWriter_Syslog(t *testing.T) {
+	t.Parallel()
+
+	addr := filepath.Join(t.TempDir(), "syslog.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: addr, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	writer, err := newWriter(Config{
+		Format:  FormatJSON,
+		Outputs: []string{"syslog"},
+		Syslog:  SyslogConfig{Network: "unixgram", Address: addr, Tag: "gohex"},
+	})
+	require.NoError(t, err)
+
+	logger := zerolog.New(writer)
+	logger.Error().Msg("connection refused")
+
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	// <27> is the error severity of the daemon facility
+	assert.Regexp(t, `^<27>.* gohex\[\d+\]: \{"level":"error","message":"connection refused"\}`, string(buf[:n]))
+}
@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logger
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+func newSyslogWriter(SyslogConfig) (zerolog.LevelWriter, error) {
+	return nil, fmt.Errorf("%w: syslog is not supported on this platform", ErrUnknownLogOutput)
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	state     State
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the state of the keys in the process, each replica limiting on its own.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+
+	var result Result
+
+	entry.state, result = limit.Take(entry.state, now)
+	entry.expiresAt = now.Add(limit.TTL())
+
+	return result, nil
+}
+
+func (s *MemoryStore) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,234 @@
+// Package ratelimit limits the rate of the requests of a key with a token bucket or a sliding
+// window. The state of the keys is kept in memory, or by a store shared by the replicas.
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	// ErrUnknownAlgorithm is returned when the provided algorithm is not recognized.
+	ErrUnknownAlgorithm = errors.New("unknown rate limit algorithm")
+	// ErrUnknownStore is returned when the provided store is not recognized.
+	ErrUnknownStore = errors.New("unknown rate limit store")
+	// ErrInvalidLimit is returned for a limit without requests or period.
+	ErrInvalidLimit = errors.New("invalid rate limit")
+)
+
+type Algorithm string
+
+const (
+	// TokenBucket allows bursts of Burst requests, refilled at Requests per Period.
+	TokenBucket Algorithm = "token_bucket"
+	// SlidingWindow allows Requests per Period, the count of the previous window being weighted
+	// by its overlap with the sliding one.
+	SlidingWindow Algorithm = "sliding_window"
+)
+
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+type Config struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED" envDefault:"false"`
+	// Store keeps the state of the keys, memory for a single replica or postgres to share it.
+	Store     string    `env:"RATE_LIMIT_STORE" envDefault:"memory"`
+	Algorithm Algorithm `env:"RATE_LIMIT_ALGORITHM" envDefault:"token_bucket"`
+	// Requests per Period is the default limit, overridden per route.
+	Requests int           `env:"RATE_LIMIT_REQUESTS" envDefault:"100"`
+	Period   time.Duration `env:"RATE_LIMIT_PERIOD" envDefault:"1m"`
+	// Burst is the size of the token bucket, Requests when 0.
+	Burst           int           `env:"RATE_LIMIT_BURST" envDefault:"0"`
+	CleanupInterval time.Duration `env:"RATE_LIMIT_CLEANUP_INTERVAL" envDefault:"5m"`
+}
+
+// Limit returns the default limit of the configuration.
+func (c Config) Limit() Limit {
+	return Limit{Requests: c.Requests, Period: c.Period, Burst: c.Burst, Algorithm: c.Algorithm}
+}
+
+type Limit struct {
+	Requests  int
+	Period    time.Duration
+	Burst     int
+	Algorithm Algorithm
+}
+
+// Validate checks the limit, the zero algorithm being a token bucket.
+func (l Limit) Validate() error {
+	if l.Requests <= 0 || l.Period <= 0 || l.Burst < 0 {
+		return fmt.Errorf("%w: %d per %s", ErrInvalidLimit, l.Requests, l.Period)
+	}
+
+	switch l.Algorithm {
+	case TokenBucket, SlidingWindow, "":
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownAlgorithm, l.Algorithm)
+	}
+}
+
+// Result is the outcome of a request. Reset is the time until the token bucket is full again,
+// or until the end of the current window.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// State is the state of a key, the token bucket using Tokens and the sliding window the counts.
+// The zero state is the one of a key without requests.
+type State struct {
+	Tokens float64
+	// WindowStart is the start of the current fixed window, Count its requests and PrevCount the
+	// requests of the previous one.
+	WindowStart time.Time
+	Count       int
+	PrevCount   int
+	UpdatedAt   time.Time
+}
+
+// Take applies a request to the state of a key.
+func (l Limit) Take(state State, now time.Time) (State, Result) {
+	var allowed bool
+
+	if l.Algorithm == SlidingWindow {
+		state, allowed = l.takeSlidingWindow(state, now)
+	} else {
+		state, allowed = l.takeTokenBucket(state, now)
+	}
+
+	return state, l.Result(state, allowed, now)
+}
+
+// Result is the outcome of a request from the state it left the key in, for the stores applying
+// the requests themselves.
+func (l Limit) Result(state State, allowed bool, now time.Time) Result {
+	if l.Algorithm == SlidingWindow {
+		return l.slidingWindowResult(state, allowed, now)
+	}
+
+	return l.tokenBucketResult(state, allowed)
+}
+
+// TTL is how long the state of a key matters after its last request.
+func (l Limit) TTL() time.Duration {
+	if l.Algorithm == SlidingWindow {
+		return 2 * l.Period
+	}
+
+	return l.refillDuration(float64(l.Capacity()))
+}
+
+// Capacity is the size of the token bucket.
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+
+	return l.Requests
+}
+
+// Window returns the start of the current fixed window and the weight of the previous one, its
+// part overlapping the sliding window.
+func (l Limit) Window(now time.Time) (time.Time, float64) {
+	start := now.Truncate(l.Period)
+
+	return start, 1 - float64(now.Sub(start))/float64(l.Period)
+}
+
+// refillDuration is the time to refill the tokens.
+func (l Limit) refillDuration(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens * float64(l.Period) / float64(l.Requests)))
+}
+
+func (l Limit) takeTokenBucket(state State, now time.Time) (State, bool) {
+	capacity := float64(l.Capacity())
+
+	tokens := capacity
+	if !state.UpdatedAt.IsZero() {
+		elapsed := max(now.Sub(state.UpdatedAt), 0)
+		tokens = min(capacity, state.Tokens+float64(elapsed)*float64(l.Requests)/float64(l.Period))
+	}
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+
+	return State{Tokens: tokens, UpdatedAt: now}, allowed
+}
+
+func (l Limit) tokenBucketResult(state State, allowed bool) Result {
+	result := Result{
+		Allowed:   allowed,
+		Limit:     l.Capacity(),
+		Remaining: int(state.Tokens),
+		Reset:     l.refillDuration(float64(l.Capacity()) - state.Tokens),
+	}
+
+	if !allowed {
+		result.RetryAfter = l.refillDuration(1 - state.Tokens)
+	}
+
+	return result
+}
+
+func (l Limit) takeSlidingWindow(state State, now time.Time) (State, bool) {
+	windowStart, weight := l.Window(now)
+
+	switch {
+	case state.WindowStart.Equal(windowStart):
+	case state.WindowStart.Equal(windowStart.Add(-l.Period)):
+		state.PrevCount, state.Count = state.Count, 0
+	default:
+		state.PrevCount, state.Count = 0, 0
+	}
+
+	state.WindowStart, state.UpdatedAt = windowStart, now
+
+	allowed := float64(state.PrevCount)*weight+float64(state.Count)+1 <= float64(l.Requests)
+	if allowed {
+		state.Count++
+	}
+
+	return state, allowed
+}
+
+func (l Limit) slidingWindowResult(state State, allowed bool, now time.Time) Result {
+	_, weight := l.Window(now)
+	elapsed := now.Sub(state.WindowStart)
+	used := float64(state.PrevCount)*weight + float64(state.Count)
+
+	result := Result{
+		Allowed:   allowed,
+		Limit:     l.Requests,
+		Remaining: max(int(float64(l.Requests)-used), 0),
+		Reset:     l.Period - elapsed,
+	}
+
+	if !allowed {
+		result.RetryAfter = l.slidingRetryAfter(state, elapsed)
+	}
+
+	return result
+}
+
+// slidingRetryAfter is the time until the weight of the previous window lets a request in.
+func (l Limit) slidingRetryAfter(state State, elapsed time.Duration) time.Duration {
+	room := float64(l.Requests - state.Count - 1)
+	if room < 0 || state.PrevCount == 0 {
+		return l.Period - elapsed
+	}
+
+	// PrevCount * (1 - at/Period) + Count + 1 <= Requests
+	at := time.Duration(math.Ceil(float64(l.Period) * (1 - room/float64(state.PrevCount))))
+
+	return max(at-elapsed, time.Nanosecond)
+}
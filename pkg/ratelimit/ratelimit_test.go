@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type take struct {
+	after    time.Duration
+	expected Result
+}
+
+func TestLimit_Take(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		limit Limit
+		takes []take
+	}{
+		{
+			name:  "token bucket burst and refill",
+			limit: Limit{Requests: 2, Period: time.Second, Burst: 3, Algorithm: TokenBucket},
+			takes: []take{
+				{expected: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+				{expected: Result{Allowed: true, Limit: 3, Remaining: 1, Reset: time.Second}},
+				{expected: Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond}},
+				{expected: Result{
+					Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond, RetryAfter: 500 * time.Millisecond,
+				}},
+				{after: 500 * time.Millisecond, expected: Result{
+					Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond,
+				}},
+				{after: time.Hour, expected: Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+			},
+		},
+		{
+			name:  "token bucket without burst",
+			limit: Limit{Requests: 1, Period: time.Minute},
+			takes: []take{
+				{expected: Result{Allowed: true, Limit: 1, Remaining: 0, Reset: time.Minute}},
+				{after: 15 * time.Second, expected: Result{
+					Limit: 1, Remaining: 0, Reset: 45 * time.Second, RetryAfter: 45 * time.Second,
+				}},
+			},
+		},
+		{
+			name:  "sliding window",
+			limit: Limit{Requests: 4, Period: time.Minute, Algorithm: SlidingWindow},
+			takes: []take{
+				{after: 30 * time.Second, expected: Result{Allowed: true, Limit: 4, Remaining: 3, Reset: 30 * time.Second}},
+				{expected: Result{Allowed: true, Limit: 4, Remaining: 2, Reset: 30 * time.Second}},
+				{expected: Result{Allowed: true, Limit: 4, Remaining: 1, Reset: 30 * time.Second}},
+				{expected: Result{Allowed: true, Limit: 4, Remaining: 0, Reset: 30 * time.Second}},
+				{expected: Result{Limit: 4, Remaining: 0, Reset: 30 * time.Second, RetryAfter: 30 * time.Second}},
+				// the 4 requests of the previous window weigh 3 a quarter into the next one
+				{after: 45 * time.Second, expected: Result{Allowed: true, Limit: 4, Remaining: 0, Reset: 45 * time.Second}},
+				{expected: Result{Limit: 4, Remaining: 0, Reset: 45 * time.Second, RetryAfter: 15 * time.Second}},
+				{after: 2 * time.Minute, expected: Result{Allowed: true, Limit: 4, Remaining: 3, Reset: 45 * time.Second}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.NoError(t, tc.limit.Validate())
+
+			now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+			var state State
+
+			for i, take := range tc.takes {
+				now = now.Add(take.after)
+
+				var result Result
+
+				state, result = tc.limit.Take(state, now)
+				assert.Equal(t, take.expected, result, "take %d", i)
+			}
+		})
+	}
+}
+
+func TestLimit_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, Limit{Period: time.Second}.Validate(), ErrInvalidLimit)
+	require.ErrorIs(t, Limit{Requests: 1}.Validate(), ErrInvalidLimit)
+	require.ErrorIs(t, Limit{Requests: 1, Period: time.Second, Algorithm: "leaky"}.Validate(), ErrUnknownAlgorithm)
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Period: time.Minute}
+	now := time.Now()
+
+	result, err := store.Take(t.Context(), "ip:127.0.0.1", limit, now)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = store.Take(t.Context(), "ip:127.0.0.1", limit, now)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	result, err = store.Take(t.Context(), "ip:127.0.0.2", limit, now)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "keys are limited apart")
+
+	deleted, err := store.DeleteExpired(t.Context(), now.Add(limit.TTL()-time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = store.DeleteExpired(t.Context(), now.Add(limit.TTL()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
@@ -0,0 +1,96 @@
+// Package tracing sets up the OpenTelemetry tracer provider and the W3C propagation of the traces.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+// ErrUnknownExporter is returned when the provided span exporter is not recognized.
+var ErrUnknownExporter = errors.New("unknown trace exporter")
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+type Config struct {
+	// Exporter is where the spans are sent: none, stdout or file. Spans are created with none as
+	// well, so that the trace IDs still are propagated and logged.
+	Exporter string `env:"TRACING_EXPORTER" envDefault:"none"`
+	// FilePath is the file the spans are appended to as JSON lines with the file exporter.
+	FilePath    string  `env:"TRACING_FILE_PATH" envDefault:"traces/spans.jsonl"`
+	ServiceName string  `env:"TRACING_SERVICE_NAME" envDefault:"go-hex"`
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+}
+
+// NewTracerProvider returns a tracer provider sampling the new traces by ratio and following the
+// decision of the parent otherwise. It must be shut down to flush the spans.
+func NewTracerProvider(cfg Config) (*sdktrace.TracerProvider, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName))),
+	}
+
+	if cfg.Exporter != ExporterNone && cfg.Exporter != "" {
+		exporter, err := newExporter(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// NewPropagator returns the propagator of the W3C trace context and baggage.
+func NewPropagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+func newExporter(cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		if err := os.MkdirAll(filepath.Dir(cfg.FilePath), 0o750); err != nil {
+			return nil, fmt.Errorf("create trace directory: %w", err)
+		}
+
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("open trace file: %w", err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			return nil, errors.Join(err, file.Close())
+		}
+
+		return &fileExporter{SpanExporter: exporter, file: file}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExporter, cfg.Exporter)
+	}
+}
+
+// fileExporter closes the file once the exporter is shut down.
+type fileExporter struct {
+	sdktrace.SpanExporter
+
+	file *os.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	return errors.Join(e.SpanExporter.Shutdown(ctx), e.file.Close())
+}
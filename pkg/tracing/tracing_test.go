@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "traces", "spans.jsonl")
+
+	provider, err := NewTracerProvider(Config{
+		Exporter: ExporterFile, FilePath: path, ServiceName: "go-hex", SampleRatio: 1,
+	})
+	require.NoError(t, err)
+
+	_, span := provider.Tracer("test").Start(t.Context(), "GET /users")
+	span.End()
+
+	require.NoError(t, provider.Shutdown(t.Context()))
+
+	spans, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(spans), `"Name":"GET /users"`)
+	assert.Contains(t, string(spans), `"Value":"go-hex"`)
+}
+
+func TestNewTracerProvider_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTracerProvider(Config{Exporter: "jaeger"})
+	require.ErrorIs(t, err, ErrUnknownExporter)
+
+	provider, err := NewTracerProvider(Config{Exporter: ExporterNone, SampleRatio: 1})
+	require.NoError(t, err)
+
+	_, span := provider.Tracer("test").Start(t.Context(), "GET /users")
+	assert.True(t, span.SpanContext().IsValid(), "spans are created without exporter")
+}
@@ -2,7 +2,8 @@ package pgx
 
 import (
 	"context"
-	"fmt"
+
+	"app/pkg/errtrace"
 )
 
 func New(pool DB) (*Transactor, DBGetter) {
@@ -40,7 +41,7 @@ func (t *Transactor) Do(ctx context.Context, txFunc func(context.Context) error)
 
 	tx, err := db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return errtrace.Errorf("failed to begin transaction: %w", err)
 	}
 
 	defer func() {
@@ -54,7 +55,7 @@ func (t *Transactor) Do(ctx context.Context, txFunc func(context.Context) error)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return errtrace.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,17 @@
+// Package transactortest provides transactors for the tests of the code running in transactions.
+package transactortest
+
+import (
+	"context"
+)
+
+// PassThrough runs the functions without transaction.
+type PassThrough struct{}
+
+func (PassThrough) Do(ctx context.Context, txFunc func(context.Context) error) error {
+	return txFunc(ctx)
+}
+
+func (PassThrough) Skip(ctx context.Context) context.Context {
+	return ctx
+}
@@ -0,0 +1,458 @@
+// Package validator validates structs against the rules of their validate tags:
+//
+//	type request struct {
+//		Username string   `json:"username" validate:"required,min=3,max=32,regex=^[a-z]+$"`
+//		OwnerID  string   `json:"owner_id" validate:"uuid"`
+//		Tags     []string `json:"tags" validate:"max=10"`
+//	}
+//
+// Every violation is reported, with the path of the field built from its json,
+// query, uri or form tag, e.g. profile.addresses[1].city.
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const tagName = "validate"
+
+// Rule names supported in validate tags. The regex rule must come last,
+// its pattern extends to the end of the tag and may contain commas.
+const (
+	RuleRequired = "required"
+	RuleMin      = "min"
+	RuleMax      = "max"
+	RuleRegex    = "regex"
+	RuleUUID     = "uuid"
+)
+
+// nameTags are the tags the field names are taken from, in order of preference.
+var nameTags = []string{"json", "query", "uri", "form"}
+
+// Units of the min and max rules, depending on the kind of the checked value.
+const (
+	UnitCharacters = "characters"
+	UnitItems      = "items"
+)
+
+// FieldError describes a field violating a rule. Unit tells what the min and max
+// rules counted, it is empty for numbers.
+type FieldError struct {
+	Path    string
+	Rule    string
+	Param   string
+	Unit    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// Errors lists every violation of a validated struct.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Message)
+	}
+
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Validator caches the rules of each validated struct type. It is safe for concurrent use.
+type Validator struct {
+	structs sync.Map // reflect.Type -> *structRules
+}
+
+func New() *Validator {
+	return &Validator{}
+}
+
+type rule struct {
+	name  string
+	param string
+	limit int
+	regex *regexp.Regexp
+}
+
+type fieldRules struct {
+	index []int
+	name  string
+	rules []rule
+}
+
+type structRules struct {
+	fields []fieldRules
+	err    error
+}
+
+// Validate checks a struct, or a pointer to one, and returns Errors listing every violation.
+// Malformed tags are reported as a plain error.
+func (v *Validator) Validate(out any) error {
+	val := reflect.ValueOf(out)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs Errors
+	if err := v.validateStruct(val, "", &errs); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (v *Validator) validateStruct(val reflect.Value, prefix string, errs *Errors) error {
+	rules, err := v.rulesOf(val.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range rules.fields {
+		fieldVal, ok := fieldByIndex(val, field.index)
+		if !ok {
+			continue
+		}
+
+		path := joinPath(prefix, field.name)
+
+		if !checkRules(fieldVal, path, field.rules, errs) {
+			continue
+		}
+
+		if err := v.validateNested(fieldVal, path, errs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateNested descends into struct fields and the elements of slices and arrays.
+func (v *Validator) validateNested(val reflect.Value, path string, errs *Errors) error {
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		return v.validateStruct(val, path, errs)
+	case reflect.Slice, reflect.Array:
+		if !hasNestedStructs(val.Type().Elem()) {
+			return nil
+		}
+
+		for i := range val.Len() {
+			if err := v.validateNested(val.Index(i), fmt.Sprintf("%s[%d]", path, i), errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) rulesOf(typ reflect.Type) (*structRules, error) {
+	if cached, ok := v.structs.Load(typ); ok {
+		rules := cached.(*structRules) //nolint:forcetypeassert // only structRules are stored
+
+		return rules, rules.err
+	}
+
+	rules := &structRules{}
+	rules.fields, rules.err = compileStruct(typ, nil)
+
+	cached, _ := v.structs.LoadOrStore(typ, rules)
+	rules = cached.(*structRules) //nolint:forcetypeassert // only structRules are stored
+
+	return rules, rules.err
+}
+
+// compileStruct collects the fields to validate, flattening embedded structs without a name tag.
+func compileStruct(typ reflect.Type, index []int) ([]fieldRules, error) {
+	var fields []fieldRules
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		name, tagged := fieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && !tagged && indirect(field.Type).Kind() == reflect.Struct {
+			embedded, err := compileStruct(indirect(field.Type), fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, embedded...)
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		rules, err := parseRules(field.Tag.Get(tagName))
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typ.Name(), field.Name, err)
+		}
+
+		if len(rules) == 0 && !hasNestedStructs(field.Type) {
+			continue
+		}
+
+		fields = append(fields, fieldRules{index: fieldIndex, name: name, rules: rules})
+	}
+
+	return fields, nil
+}
+
+func parseRules(tag string) ([]rule, error) {
+	var rules []rule
+
+	for tag != "" {
+		var part string
+		if strings.HasPrefix(tag, RuleRegex+"=") {
+			part, tag = tag, ""
+		} else {
+			part, tag, _ = strings.Cut(tag, ",")
+		}
+
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		r := rule{name: name, param: param}
+
+		switch name {
+		case RuleRequired, RuleUUID:
+		case RuleMin, RuleMax:
+			limit, err := strconv.Atoi(param)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s needs an integer parameter, got %q", name, param)
+			}
+
+			r.limit = limit
+		case RuleRegex:
+			regex, err := regexp.Compile(param)
+			if err != nil {
+				return nil, fmt.Errorf("rule regex: %w", err)
+			}
+
+			r.regex = regex
+		default:
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+// checkRules appends the violations of the value and reports whether it is worth descending into.
+func checkRules(val reflect.Value, path string, rules []rule, errs *Errors) bool {
+	if isEmpty(val) {
+		for _, r := range rules {
+			if r.name == RuleRequired {
+				*errs = append(*errs, newFieldError(path, r, "", "is required"))
+			}
+		}
+
+		// The fields of a zero struct are still checked, so their own required rules apply.
+		return val.Kind() == reflect.Struct
+	}
+
+	val = reflect.Indirect(val)
+
+	for _, r := range rules {
+		if message, unit, ok := check(val, r); !ok {
+			*errs = append(*errs, newFieldError(path, r, unit, message))
+		}
+	}
+
+	return true
+}
+
+// check returns the message and the unit of a violation and whether the value passes the rule.
+func check(val reflect.Value, r rule) (string, string, bool) {
+	switch r.name {
+	case RuleMin:
+		return checkBound(val, r.limit, "at least", func(n, limit float64) bool { return n >= limit })
+	case RuleMax:
+		return checkBound(val, r.limit, "at most", func(n, limit float64) bool { return n <= limit })
+	case RuleRegex:
+		if val.Kind() != reflect.String {
+			return "must be a string", "", false
+		}
+
+		return "must match " + r.regex.String(), "", r.regex.MatchString(val.String())
+	case RuleUUID:
+		if val.Kind() != reflect.String {
+			return "must be a string", "", false
+		}
+
+		_, err := uuid.Parse(val.String())
+
+		return "must be a valid UUID", "", err == nil
+	default:
+		return "", "", true
+	}
+}
+
+// checkBound compares the length of strings, slices and maps or the value of numbers.
+func checkBound(val reflect.Value, limit int, bound string, ok func(n, limit float64) bool) (string, string, bool) {
+	var (
+		n    float64
+		unit string
+	)
+
+	switch val.Kind() {
+	case reflect.String:
+		n, unit = float64(utf8.RuneCountInString(val.String())), UnitCharacters
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, unit = float64(val.Len()), UnitItems
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = val.Float()
+	default:
+		return "has no length", "", false
+	}
+
+	message := fmt.Sprintf("must be %s %d", bound, limit)
+
+	switch {
+	case unit == UnitItems && limit == 1:
+		message += " item"
+	case unit != "":
+		message += " " + unit
+	}
+
+	return message, unit, ok(n, float64(limit))
+}
+
+func newFieldError(path string, r rule, unit, message string) FieldError {
+	return FieldError{
+		Path:    path,
+		Rule:    r.name,
+		Param:   r.param,
+		Unit:    unit,
+		Message: path + " " + message,
+	}
+}
+
+func fieldName(field reflect.StructField) (string, bool) {
+	for _, tag := range nameTags {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" {
+			return name, true
+		}
+	}
+
+	return field.Name, false
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex stopping at nil embedded pointers.
+func fieldByIndex(val reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 {
+			if val.Kind() == reflect.Pointer {
+				if val.IsNil() {
+					return reflect.Value{}, false
+				}
+
+				val = val.Elem()
+			}
+		}
+
+		val = val.Field(idx)
+	}
+
+	return val, true
+}
+
+// hasNestedStructs reports whether values of the type may hold structs to validate.
+// Structs of other packages, e.g. time.Time, are validated only when they carry validate tags.
+func hasNestedStructs(typ reflect.Type) bool {
+	return hasTaggedFields(typ, make(map[reflect.Type]bool))
+}
+
+func hasTaggedFields(typ reflect.Type, visited map[reflect.Type]bool) bool {
+	typ = indirect(typ)
+	if visited[typ] {
+		return false
+	}
+
+	visited[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			if field.Tag.Get(tagName) != "" || (field.IsExported() && hasTaggedFields(field.Type, visited)) {
+				return true
+			}
+		}
+
+		return false
+	case reflect.Slice, reflect.Array:
+		return hasTaggedFields(typ.Elem(), visited)
+	default:
+		return false
+	}
+}
+
+func indirect(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+// isEmpty reports whether the value is missing: nil, zero or an empty collection.
+func isEmpty(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Slice, reflect.Map:
+		return val.Len() == 0
+	default:
+		return val.IsZero()
+	}
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type profile struct {
+	DisplayName string     `json:"display_name" validate:"max=5"`
+	Addresses   []address  `json:"addresses" validate:"max=2"`
+	Primary     *address   `json:"primary"`
+	Tags        []string   `json:"tags" validate:"min=1"`
+	Score       int        `json:"score" validate:"min=1,max=10"`
+	Parent      *profile   `json:"parent"`
+	Pagination  pagination `json:"-"`
+}
+
+type pagination struct {
+	Limit int `query:"limit" validate:"max=100"`
+}
+
+type request struct {
+	pagination
+
+	Username string  `json:"username" validate:"required,min=3,regex=^[a-z]+(,[a-z]+)?$"`
+	OwnerID  string  `json:"owner_id" validate:"uuid"`
+	Profile  profile `json:"profile"`
+	Note     *string `json:"note" validate:"required"`
+	internal string
+}
+
+func TestValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	note := "note"
+
+	testCases := []struct {
+		name           string
+		input          any
+		expectedErrors Errors
+	}{
+		{
+			name: "Valid",
+			input: &request{
+				Username: "john,doe",
+				OwnerID:  "0199a3c4-5b6e-7f80-9a1b-2c3d4e5f6a7b",
+				Profile: profile{
+					Addresses: []address{{City: "Kyiv"}},
+					Tags:      []string{"a"},
+					Score:     5,
+				},
+				Note: &note,
+			},
+		},
+		{
+			name: "Every Violation",
+			input: request{
+				pagination: pagination{Limit: 101},
+				Username:   "Jo",
+				OwnerID:    "not-a-uuid",
+				Profile: profile{
+					DisplayName: "too long",
+					Addresses:   []address{{City: "Kyiv"}, {}, {}},
+					Primary:     &address{},
+					Parent:      &profile{Score: 11, Tags: []string{"a"}},
+				},
+			},
+			expectedErrors: Errors{
+				{Path: "limit", Rule: RuleMax, Param: "100", Message: "limit must be at most 100"},
+				{Path: "username", Rule: RuleMin, Param: "3", Unit: UnitCharacters, Message: "username must be at least 3 characters"},
+				{
+					Path: "username", Rule: RuleRegex, Param: "^[a-z]+(,[a-z]+)?$",
+					Message: "username must match ^[a-z]+(,[a-z]+)?$",
+				},
+				{Path: "owner_id", Rule: RuleUUID, Message: "owner_id must be a valid UUID"},
+				{Path: "profile.display_name", Rule: RuleMax, Param: "5", Unit: UnitCharacters, Message: "profile.display_name must be at most 5 characters"},
+				{Path: "profile.addresses", Rule: RuleMax, Param: "2", Unit: UnitItems, Message: "profile.addresses must be at most 2 items"},
+				{Path: "profile.addresses[1].city", Rule: RuleRequired, Message: "profile.addresses[1].city is required"},
+				{Path: "profile.addresses[2].city", Rule: RuleRequired, Message: "profile.addresses[2].city is required"},
+				{Path: "profile.primary.city", Rule: RuleRequired, Message: "profile.primary.city is required"},
+				{Path: "profile.parent.score", Rule: RuleMax, Param: "10", Message: "profile.parent.score must be at most 10"},
+				{Path: "note", Rule: RuleRequired, Message: "note is required"},
+			},
+		},
+		{
+			name:  "Empty Optional Fields",
+			input: &request{Username: "john", Note: &note},
+		},
+		{
+			name:  "Not A Struct",
+			input: "value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := New().Validate(tc.input)
+
+			if tc.expectedErrors == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var errs Errors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tc.expectedErrors, errs)
+		})
+	}
+}
+
+func TestValidator_ValidateMalformedTag(t *testing.T) {
+	t.Parallel()
+
+	type malformed struct {
+		Name string `validate:"min=three"`
+	}
+
+	validator := New()
+
+	for range 2 {
+		err := validator.Validate(malformed{})
+		require.ErrorContains(t, err, "rule min needs an integer parameter")
+		assert.NotErrorAs(t, err, new(Errors))
+	}
+}